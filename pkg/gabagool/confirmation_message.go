@@ -38,6 +38,15 @@ type confirmationMessageSettings struct {
 	InputDelay       time.Duration
 }
 
+type confirmationMessage struct {
+	window       *internal.Window
+	settings     confirmationMessageSettings
+	imageTexture *sdl.Texture
+	imageRect    sdl.Rect
+	result       ConfirmationResult
+	done         bool
+}
+
 func defaultMessageSettings(message string) confirmationMessageSettings {
 	return confirmationMessageSettings{
 		Margins:          internal.UniformPadding(20),
@@ -77,29 +86,31 @@ func ConfirmationMessage(message string, footerHelpItems []FooterHelpItem, optio
 		settings.CancelButton = options.CancelButton
 	}
 
-	result := ConfirmationResult{Confirmed: false}
-	lastInputTime := time.Now()
+	cm := &confirmationMessage{
+		window:   window,
+		settings: settings,
+		result:   ConfirmationResult{Confirmed: false},
+	}
 
-	imageTexture, imageRect := loadAndPrepareImage(renderer, settings)
+	cm.imageTexture, cm.imageRect = loadAndPrepareImage(renderer, settings)
 	defer func() {
-		if imageTexture != nil {
-			imageTexture.Destroy()
+		if cm.imageTexture != nil {
+			cm.imageTexture.Destroy()
 		}
 	}()
 
-	for {
-		if !handleEvents(&result, &lastInputTime, settings) {
-			break
-		}
+	screenOptions := DefaultScreenOptions()
+	screenOptions.InputDelay = settings.InputDelay
+	screenOptions.RepeatButtons = nil
 
-		renderFrame(renderer, window, settings, imageTexture, imageRect)
-		sdl.Delay(16)
+	if RunScreen(cm, screenOptions) {
+		cm.result.Confirmed = false
 	}
 
-	if !result.Confirmed {
+	if !cm.result.Confirmed {
 		return nil, ErrCancelled
 	}
-	return &result, nil
+	return &cm.result, nil
 }
 
 func loadAndPrepareImage(renderer *sdl.Renderer, settings confirmationMessageSettings) (*sdl.Texture, sdl.Rect) {
@@ -134,42 +145,29 @@ func loadAndPrepareImage(renderer *sdl.Renderer, settings confirmationMessageSet
 	}
 }
 
-func handleEvents(result *ConfirmationResult, lastInputTime *time.Time, settings confirmationMessageSettings) bool {
-	processor := internal.GetInputProcessor()
-
-	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-		switch event.(type) {
-		case *sdl.QuitEvent:
-			result.Confirmed = false
-			return false
-
-		case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
-			inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-			if inputEvent == nil || !inputEvent.Pressed {
-				continue
-			}
-
-			if !isInputAllowed(*lastInputTime, settings.InputDelay) {
-				continue
-			}
-
-			*lastInputTime = time.Now()
-
-			switch inputEvent.Button {
-			case settings.ConfirmButton, constants.VirtualButtonStart:
-				result.Confirmed = true
-				return false
-			case settings.CancelButton:
-				result.Confirmed = false
-				return false
-			}
-		}
+func (cm *confirmationMessage) HandleInput(inputEvent *InputEvent) {
+	if !inputEvent.Pressed {
+		return
+	}
+
+	switch inputEvent.Button {
+	case cm.settings.ConfirmButton, constants.VirtualButtonStart:
+		cm.result.Confirmed = true
+		cm.done = true
+	case cm.settings.CancelButton:
+		cm.result.Confirmed = false
+		cm.done = true
 	}
-	return true
 }
 
-func isInputAllowed(lastInputTime time.Time, inputDelay time.Duration) bool {
-	return time.Since(lastInputTime) >= inputDelay
+func (cm *confirmationMessage) Update() {}
+
+func (cm *confirmationMessage) Render(renderer *sdl.Renderer) {
+	renderFrame(renderer, cm.window, cm.settings, cm.imageTexture, cm.imageRect)
+}
+
+func (cm *confirmationMessage) Done() bool {
+	return cm.done
 }
 
 func renderFrame(renderer *sdl.Renderer, window *internal.Window, settings confirmationMessageSettings, imageTexture *sdl.Texture, imageRect sdl.Rect) {
@@ -210,15 +208,13 @@ func renderFrame(renderer *sdl.Renderer, window *internal.Window, settings confi
 			constants.TextAlignCenter)
 	}
 
-	renderFooter(
+	RenderFooter(
 		renderer,
 		internal.Fonts.SmallFont,
 		settings.FooterHelpItems,
 		settings.Margins.Bottom,
 		false,
 	)
-
-	renderer.Present()
 }
 
 func calculateContentHeight(settings confirmationMessageSettings, imageRect sdl.Rect) int32 {
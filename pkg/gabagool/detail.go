@@ -6,7 +6,6 @@ import (
 
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
-	"github.com/veandco/go-sdl2/img"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
@@ -54,36 +53,31 @@ type DetailScreenResult struct {
 }
 
 type detailScreenState struct {
-	window                 *internal.Window
-	renderer               *sdl.Renderer
-	options                DetailScreenOptions
-	footerHelpItems        []FooterHelpItem
-	scrollY                int32
-	targetScrollY          int32
-	maxScrollY             int32
-	scrollSpeed            int32
-	scrollAnimationSpeed   float32
-	lastInputTime          time.Time
-	inputDelay             time.Duration
-	slideshowStates        map[int]slideshowState
-	textureCache           *internal.TextureCache
-	titleTexture           *sdl.Texture
-	sectionTitleTextures   []*sdl.Texture
-	metadataLabelTextures  map[int][]*sdl.Texture
-	heldDirections         struct{ up, down bool }
-	lastRepeatTime         time.Time
-	repeatDelay            time.Duration
-	repeatInterval         time.Duration
-	result                 DetailScreenResult
-	activeSlideshow        int
-	lastDirectionPressTime time.Time
-	directionTimeout       time.Duration
+	window                *internal.Window
+	renderer              *sdl.Renderer
+	options               DetailScreenOptions
+	footerHelpItems       []FooterHelpItem
+	scrollY               int32
+	targetScrollY         int32
+	maxScrollY            int32
+	scrollSpeed           int32
+	scrollAnimationSpeed  float32
+	inputDelay            time.Duration
+	slideshowStates       map[int]slideshowState
+	textureCache          *internal.TextureCache
+	titleTexture          *sdl.Texture
+	sectionTitleTextures  []*sdl.Texture
+	metadataLabelTextures map[int][]*sdl.Texture
+	result                DetailScreenResult
+	activeSlideshow       int
 }
 
 type slideshowState struct {
 	currentIndex int
-	textures     []*sdl.Texture
-	dimensions   []sdl.Rect
+	paths        []string
+	section      Section
+	maxWidth     int32
+	maxHeight    int32
 }
 
 func DefaultInfoScreenOptions() DetailScreenOptions {
@@ -141,11 +135,8 @@ func DetailScreen(title string, options DetailScreenOptions, footerHelpItems []F
 	state := initializeDetailScreenState(title, options, footerHelpItems)
 	defer state.cleanup()
 
-	for !state.isFinished() {
-		state.handleEvents()
-		state.update()
-		state.render()
-		sdl.Delay(16)
+	if RunScreen(state, state.screenOptions()) {
+		state.result.Action = DetailActionCancelled
 	}
 
 	if state.result.Action == DetailActionCancelled {
@@ -163,15 +154,11 @@ func initializeDetailScreenState(title string, options DetailScreenOptions, foot
 		footerHelpItems:       footerHelpItems,
 		scrollSpeed:           85,
 		scrollAnimationSpeed:  0.15,
-		lastInputTime:         time.Now(),
 		inputDelay:            constants.DefaultInputDelay,
 		slideshowStates:       make(map[int]slideshowState),
 		textureCache:          internal.NewTextureCache(),
 		metadataLabelTextures: make(map[int][]*sdl.Texture),
-		repeatDelay:           time.Millisecond * 150,
-		repeatInterval:        time.Millisecond * 50,
 		result:                DetailScreenResult{Action: DetailActionNone},
-		directionTimeout:      time.Millisecond * 200,
 	}
 
 	state.initializeImageDefaults()
@@ -216,7 +203,7 @@ func (s *detailScreenState) initializeSlideshows() {
 	for i, section := range s.options.Sections {
 		if section.Type == SectionTypeSlideshow || section.Type == SectionTypeImage {
 			state := s.createSlideshowState(section)
-			if len(state.textures) > 0 {
+			if len(state.paths) > 0 {
 				s.slideshowStates[i] = state
 			}
 		}
@@ -238,57 +225,29 @@ func (s *detailScreenState) createSlideshowState(section Section) slideshowState
 		imagesToLoad = imagesToLoad[:1]
 	}
 
-	var textures []*sdl.Texture
-	var dimensions []sdl.Rect
-
-	for _, imagePath := range imagesToLoad {
-		texture, rect := s.loadAndScaleImage(imagePath, maxWidth, maxHeight, section)
-		if texture != nil {
-			textures = append(textures, texture)
-			dimensions = append(dimensions, rect)
-		}
-	}
-
+	// Images are requested from the loader when they are rendered, so the slide on screen is decoded first
 	return slideshowState{
 		currentIndex: 0,
-		textures:     textures,
-		dimensions:   dimensions,
-	}
-}
-
-func (s *detailScreenState) loadAndScaleImage(imagePath string, maxWidth, maxHeight int32, section Section) (*sdl.Texture, sdl.Rect) {
-	image, err := img.Load(imagePath)
-	if err != nil || image == nil {
-		return nil, sdl.Rect{}
-	}
-	defer image.Free()
-
-	imageW, imageH := s.calculateScaledDimensions(image.W, image.H, maxWidth, maxHeight)
-	texture, err := s.renderer.CreateTextureFromSurface(image)
-	if err != nil {
+		paths:        imagesToLoad,
+		section:      section,
+		maxWidth:     maxWidth,
+		maxHeight:    maxHeight,
+	}
+}
+
+// slideshowImage returns the texture and placement of an image of the slideshow.
+// While the image is loading its full height is reserved; an image that failed to load takes no space.
+func (s *detailScreenState) slideshowImage(state slideshowState, index int) (*sdl.Texture, sdl.Rect) {
+	texture, status := internal.GetImageLoader().Texture(state.paths[index], state.maxWidth, state.maxHeight)
+	switch status {
+	case internal.ImageReady:
+		_, _, imageW, imageH, _ := texture.Query()
+		return texture, sdl.Rect{X: s.calculateImageX(imageW, state.section), W: imageW, H: imageH}
+	case internal.ImageLoading:
+		return nil, sdl.Rect{H: state.maxHeight}
+	default:
 		return nil, sdl.Rect{}
 	}
-
-	imageX := s.calculateImageX(imageW, section)
-	return texture, sdl.Rect{X: imageX, Y: 0, W: imageW, H: imageH}
-}
-
-func (s *detailScreenState) calculateScaledDimensions(originalW, originalH, maxW, maxH int32) (int32, int32) {
-	imageW, imageH := originalW, originalH
-
-	if imageW > maxW {
-		ratio := float32(maxW) / float32(imageW)
-		imageW = maxW
-		imageH = int32(float32(imageH) * ratio)
-	}
-
-	if imageH > maxH {
-		ratio := float32(maxH) / float32(imageH)
-		imageH = maxH
-		imageW = int32(float32(imageW) * ratio)
-	}
-
-	return imageW, imageH
 }
 
 func (s *detailScreenState) calculateImageX(imageW int32, section Section) int32 {
@@ -306,44 +265,37 @@ func (s *detailScreenState) calculateImageX(imageW int32, section Section) int32
 	return (s.window.GetWidth() - imageW) / 2
 }
 
-func (s *detailScreenState) isFinished() bool {
-	return s.result.Action != DetailActionNone
+func (s *detailScreenState) screenOptions() ScreenOptions {
+	options := DefaultScreenOptions()
+	options.InputDelay = s.inputDelay
+	options.RepeatButtons = []constants.VirtualButton{constants.VirtualButtonUp, constants.VirtualButtonDown}
+	return options
 }
 
-func (s *detailScreenState) handleEvents() {
-	processor := internal.GetInputProcessor()
+func (s *detailScreenState) HandleInput(inputEvent *InputEvent) {
+	if inputEvent.Pressed {
+		s.handleInputEvent(inputEvent)
+	}
+}
 
-	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-		switch event.(type) {
-		case *sdl.QuitEvent:
-			s.result.Action = DetailActionCancelled
-			return
-		case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
-			inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-			if inputEvent == nil {
-				continue
-			}
+func (s *detailScreenState) Update() {
+	s.scrollY += int32(float32(s.targetScrollY-s.scrollY) * s.scrollAnimationSpeed)
+}
 
-			if inputEvent.Pressed {
-				s.handleInputEvent(inputEvent)
-			} else {
-				s.handleInputEventRelease(inputEvent)
-			}
-		}
-	}
+func (s *detailScreenState) Render(renderer *sdl.Renderer) {
+	s.render()
 }
 
-func (s *detailScreenState) handleInputEvent(inputEvent *internal.Event) {
-	if !s.isInputAllowed() {
-		return
-	}
-	s.lastInputTime = time.Now()
+func (s *detailScreenState) Done() bool {
+	return s.result.Action != DetailActionNone
+}
 
+func (s *detailScreenState) handleInputEvent(inputEvent *internal.Event) {
 	switch inputEvent.Button {
 	case constants.VirtualButtonUp:
-		s.startScrolling(true)
+		s.targetScrollY = internal.Max32(0, s.targetScrollY-s.scrollSpeed)
 	case constants.VirtualButtonDown:
-		s.startScrolling(false)
+		s.targetScrollY = internal.Min32(s.maxScrollY, s.targetScrollY+s.scrollSpeed)
 	case constants.VirtualButtonLeft, constants.VirtualButtonRight:
 		s.handleSlideshowNavigation(inputEvent.Button == constants.VirtualButtonLeft)
 	case constants.VirtualButtonB:
@@ -357,41 +309,14 @@ func (s *detailScreenState) handleInputEvent(inputEvent *internal.Event) {
 	}
 }
 
-func (s *detailScreenState) handleInputEventRelease(inputEvent *internal.Event) {
-	switch inputEvent.Button {
-	case constants.VirtualButtonUp:
-		s.heldDirections.up = false
-	case constants.VirtualButtonDown:
-		s.heldDirections.down = false
-	}
-}
-
-func (s *detailScreenState) isInputAllowed() bool {
-	return time.Since(s.lastInputTime) >= s.inputDelay
-}
-
-func (s *detailScreenState) startScrolling(up bool) {
-	if up {
-		s.heldDirections.up = true
-		s.heldDirections.down = false
-		s.targetScrollY = internal.Max32(0, s.targetScrollY-s.scrollSpeed)
-	} else {
-		s.heldDirections.down = true
-		s.heldDirections.up = false
-		s.targetScrollY = internal.Min32(s.maxScrollY, s.targetScrollY+s.scrollSpeed)
-	}
-	s.lastRepeatTime = time.Now()
-	s.lastDirectionPressTime = time.Now()
-}
-
 func (s *detailScreenState) handleSlideshowNavigation(isLeft bool) {
 	activeSlideshow := s.findActiveSlideshow()
 	if activeSlideshow >= 0 {
-		if state, ok := s.slideshowStates[activeSlideshow]; ok && len(state.textures) > 1 {
+		if state, ok := s.slideshowStates[activeSlideshow]; ok && len(state.paths) > 1 {
 			if isLeft {
-				state.currentIndex = (state.currentIndex - 1 + len(state.textures)) % len(state.textures)
+				state.currentIndex = (state.currentIndex - 1 + len(state.paths)) % len(state.paths)
 			} else {
-				state.currentIndex = (state.currentIndex + 1) % len(state.textures)
+				state.currentIndex = (state.currentIndex + 1) % len(state.paths)
 			}
 			s.slideshowStates[activeSlideshow] = state
 		}
@@ -402,41 +327,6 @@ func (s *detailScreenState) findActiveSlideshow() int {
 	return s.activeSlideshow
 }
 
-func (s *detailScreenState) update() {
-	s.handleDirectionalRepeats()
-	s.scrollY += int32(float32(s.targetScrollY-s.scrollY) * s.scrollAnimationSpeed)
-}
-
-func (s *detailScreenState) handleDirectionalRepeats() {
-	now := time.Now()
-
-	// Reset held directions if no input received recently (handles missing release events)
-	if now.Sub(s.lastDirectionPressTime) > s.directionTimeout {
-		s.heldDirections.up = false
-		s.heldDirections.down = false
-		return
-	}
-
-	timeSinceLastRepeat := now.Sub(s.lastRepeatTime)
-
-	if timeSinceLastRepeat < s.repeatDelay {
-		return
-	}
-	if s.repeatInterval > 0 && timeSinceLastRepeat < s.repeatInterval {
-		return
-	}
-
-	if s.heldDirections.up {
-		s.targetScrollY = internal.Max32(0, s.targetScrollY-s.scrollSpeed)
-		s.lastRepeatTime = now
-		s.lastDirectionPressTime = now
-	} else if s.heldDirections.down {
-		s.targetScrollY = internal.Min32(s.maxScrollY, s.targetScrollY+s.scrollSpeed)
-		s.lastRepeatTime = now
-		s.lastDirectionPressTime = now
-	}
-}
-
 func (s *detailScreenState) render() {
 	s.clearScreen()
 
@@ -450,8 +340,6 @@ func (s *detailScreenState) render() {
 	s.updateScrollLimits(totalContentHeight, safeAreaHeight, margins)
 	s.renderScrollbar(safeAreaHeight)
 	s.renderFooter(margins)
-
-	s.renderer.Present()
 }
 
 func (s *detailScreenState) clearScreen() {
@@ -567,22 +455,24 @@ func (s *detailScreenState) renderSectionContent(sectionIndex int, section Secti
 
 func (s *detailScreenState) renderSlideshow(sectionIndex int, currentY int32, safeAreaHeight int32) int32 {
 	state, ok := s.slideshowStates[sectionIndex]
-	if !ok || len(state.textures) == 0 {
+	if !ok || len(state.paths) == 0 {
 		return currentY
 	}
 
-	imageRect := state.dimensions[state.currentIndex]
+	texture, imageRect := s.slideshowImage(state, state.currentIndex)
 	imageRect.Y = currentY
 
 	if isRectVisible(imageRect, safeAreaHeight) {
-		s.renderer.Copy(state.textures[state.currentIndex], nil, &imageRect)
+		if texture != nil {
+			s.renderer.Copy(texture, nil, &imageRect)
+		}
 		// Set this as the active slideshow when it's being rendered and visible
 		s.activeSlideshow = sectionIndex
 	}
 
 	currentY += imageRect.H + 15
 
-	if len(state.textures) > 1 {
+	if len(state.paths) > 1 {
 		currentY = s.renderSlideshowIndicators(state, currentY)
 	}
 
@@ -592,12 +482,12 @@ func (s *detailScreenState) renderSlideshow(sectionIndex int, currentY int32, sa
 func (s *detailScreenState) renderSlideshowIndicators(state slideshowState, currentY int32) int32 {
 	indicatorSize := int32(10)
 	indicatorSpacing := int32(5)
-	totalIndicatorsWidth := (indicatorSize * int32(len(state.textures))) + (indicatorSpacing * int32(len(state.textures)-1))
+	totalIndicatorsWidth := (indicatorSize * int32(len(state.paths))) + (indicatorSpacing * int32(len(state.paths)-1))
 
 	indicatorX := (s.window.GetWidth() - totalIndicatorsWidth) / 2
 	indicatorY := currentY
 
-	for i := 0; i < len(state.textures); i++ {
+	for i := 0; i < len(state.paths); i++ {
 		if i == state.currentIndex {
 			s.renderer.SetDrawColor(255, 255, 255, 255)
 		} else {
@@ -620,15 +510,15 @@ func (s *detailScreenState) renderSlideshowIndicators(state slideshowState, curr
 
 func (s *detailScreenState) renderImage(sectionIndex int, currentY int32, safeAreaHeight int32) int32 {
 	state, ok := s.slideshowStates[sectionIndex]
-	if !ok || len(state.textures) == 0 {
+	if !ok || len(state.paths) == 0 {
 		return currentY
 	}
 
-	imageRect := state.dimensions[0]
+	texture, imageRect := s.slideshowImage(state, 0)
 	imageRect.Y = currentY
 
-	if isRectVisible(imageRect, safeAreaHeight) {
-		s.renderer.Copy(state.textures[0], nil, &imageRect)
+	if texture != nil && isRectVisible(imageRect, safeAreaHeight) {
+		s.renderer.Copy(texture, nil, &imageRect)
 	}
 
 	return currentY + imageRect.H + 15
@@ -754,7 +644,7 @@ func (s *detailScreenState) renderScrollbar(safeAreaHeight int32) {
 
 func (s *detailScreenState) renderFooter(margins internal.Padding) {
 	if len(s.footerHelpItems) > 0 {
-		renderFooter(
+		RenderFooter(
 			s.renderer,
 			internal.Fonts.SmallFont,
 			s.footerHelpItems,
@@ -784,12 +674,6 @@ func (s *detailScreenState) cleanup() {
 			}
 		}
 	}
-
-	for _, state := range s.slideshowStates {
-		for _, texture := range state.textures {
-			texture.Destroy()
-		}
-	}
 }
 
 func renderText(renderer *sdl.Renderer, text string, font *ttf.Font, color sdl.Color) *sdl.Texture {
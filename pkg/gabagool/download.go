@@ -1,12 +1,14 @@
 package gabagool
 
 import (
+	"context"
 	"crypto/tls"
 	"fmt"
 	"io"
 	"net/http"
 	"os"
 	"path/filepath"
+	"sync"
 	"time"
 
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
@@ -38,6 +40,8 @@ type DownloadResult struct {
 type DownloadManagerOptions struct {
 	AutoContinue  bool
 	MaxConcurrent int
+	// Context cancels all downloads once done. Partially written files are removed.
+	Context context.Context
 }
 
 type downloadJob struct {
@@ -74,11 +78,15 @@ type downloadManager struct {
 
 	scrollOffset int32
 
-	headers       map[string]string
-	lastInputTime time.Time
-	inputDelay    time.Duration
+	headers    map[string]string
+	inputDelay time.Duration
 
-	showSpeed bool
+	ctx          context.Context
+	jobs         sync.WaitGroup
+	showSpeed    bool
+	autoContinue bool
+	done         bool
+	cancelled    bool
 }
 
 func newDownloadManager(downloads []Download, headers map[string]string) *downloadManager {
@@ -106,8 +114,8 @@ func newDownloadManager(downloads []Download, headers map[string]string) *downlo
 		progressBarHeight:  progressBarHeight,
 		progressBarX:       progressBarX,
 		scrollOffset:       0,
-		lastInputTime:      time.Now(),
 		inputDelay:         constants.DefaultInputDelay,
+		ctx:                internal.AppContext(),
 		showSpeed:          false,
 	}
 }
@@ -120,21 +128,20 @@ func DownloadManager(downloads []Download, headers map[string]string, opts Downl
 	if opts.MaxConcurrent > 0 {
 		downloadManager.maxConcurrent = opts.MaxConcurrent
 	}
+	downloadManager.autoContinue = opts.AutoContinue
+	if opts.Context != nil {
+		downloadManager.ctx = opts.Context
+	}
 
 	result := DownloadResult{
 		Completed: []Download{},
 		Failed:    []DownloadError{},
 	}
-	cancelled := false
 
 	if len(downloads) == 0 {
 		return &result, nil
 	}
 
-	window := internal.GetWindow()
-	renderer := window.Renderer
-	processor := internal.GetInputProcessor()
-
 	for _, download := range downloads {
 		timeout := download.Timeout
 		if timeout == 0 {
@@ -154,72 +161,24 @@ func DownloadManager(downloads []Download, headers map[string]string, opts Downl
 
 	downloadManager.startNextDownloads()
 
-	downloadManager.render(renderer)
-	renderer.Present()
-
-	running := true
-	var err error
-
-	for running {
-		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-			switch event.(type) {
-			case *sdl.QuitEvent:
-				running = false
-				err = sdl.GetError()
-				downloadManager.cancelAllDownloads()
-				cancelled = true
-
-			case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
-				inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-				if inputEvent == nil || !inputEvent.Pressed {
-					continue
-				}
-
-				if !downloadManager.isInputAllowed() {
-					continue
-				}
-				downloadManager.lastInputTime = time.Now()
-
-				if downloadManager.isAllComplete {
-					running = false
-					continue
-				}
+	screenOptions := DefaultScreenOptions()
+	screenOptions.InputDelay = downloadManager.inputDelay
+	screenOptions.RepeatButtons = nil
+	screenOptions.Context = downloadManager.ctx
 
-				if inputEvent.Button == constants.VirtualButtonY {
-					downloadManager.cancelAllDownloads()
-					cancelled = true
-				} else if inputEvent.Button == constants.VirtualButtonX {
-					downloadManager.showSpeed = !downloadManager.showSpeed
-				}
-			}
+	if RunScreen(downloadManager, screenOptions) {
+		downloadManager.cancelAllDownloads()
+		downloadManager.jobs.Wait()
+		if err := downloadManager.ctx.Err(); err != nil {
+			return nil, err
 		}
-
-		downloadManager.updateJobStatus()
-
-		if len(downloadManager.activeJobs) < downloadManager.maxConcurrent && len(downloadManager.downloadQueue) > 0 {
-			downloadManager.startNextDownloads()
+		if err := sdl.GetError(); err != nil {
+			return nil, err
 		}
-
-		if len(downloadManager.activeJobs) == 0 && len(downloadManager.downloadQueue) == 0 && !downloadManager.isAllComplete {
-			downloadManager.isAllComplete = true
-
-			if opts.AutoContinue && len(downloadManager.failedDownloads) == 0 {
-				running = false
-				continue
-			}
-		}
-
-		downloadManager.render(renderer)
-		renderer.Present()
-
-		sdl.Delay(16)
+		downloadManager.cancelled = true
 	}
 
-	if err != nil {
-		return nil, err
-	}
-
-	if cancelled {
+	if downloadManager.cancelled {
 		return nil, ErrCancelled
 	}
 
@@ -240,8 +199,46 @@ func DownloadManager(downloads []Download, headers map[string]string, opts Downl
 	return &result, nil
 }
 
-func (dm *downloadManager) isInputAllowed() bool {
-	return time.Since(dm.lastInputTime) >= dm.inputDelay
+func (dm *downloadManager) HandleInput(inputEvent *InputEvent) {
+	if !inputEvent.Pressed {
+		return
+	}
+
+	if dm.isAllComplete {
+		dm.done = true
+		return
+	}
+
+	if inputEvent.Button == constants.VirtualButtonY {
+		dm.cancelAllDownloads()
+		dm.cancelled = true
+	} else if inputEvent.Button == constants.VirtualButtonX {
+		dm.showSpeed = !dm.showSpeed
+	}
+}
+
+func (dm *downloadManager) Update() {
+	dm.updateJobStatus()
+
+	if len(dm.activeJobs) < dm.maxConcurrent && len(dm.downloadQueue) > 0 {
+		dm.startNextDownloads()
+	}
+
+	if len(dm.activeJobs) == 0 && len(dm.downloadQueue) == 0 && !dm.isAllComplete {
+		dm.isAllComplete = true
+
+		if dm.autoContinue && len(dm.failedDownloads) == 0 {
+			dm.done = true
+		}
+	}
+}
+
+func (dm *downloadManager) Render(renderer *sdl.Renderer) {
+	dm.render(renderer)
+}
+
+func (dm *downloadManager) Done() bool {
+	return dm.done
 }
 
 func (dm *downloadManager) getAverageSpeed() float64 {
@@ -276,7 +273,11 @@ func (dm *downloadManager) startNextDownloads() {
 		dm.downloadQueue = dm.downloadQueue[1:]
 		dm.activeJobs = append(dm.activeJobs, job)
 
-		go dm.downloadFile(job)
+		dm.jobs.Add(1)
+		go func() {
+			defer dm.jobs.Done()
+			dm.downloadFile(job)
+		}()
 	}
 }
 
@@ -331,7 +332,10 @@ func (dm *downloadManager) downloadFile(job *downloadJob) {
 		return
 	}
 
-	req, err := http.NewRequest("GET", url, nil)
+	ctx, cancel := context.WithCancel(dm.ctx)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
 	if err != nil {
 		job.hasError = true
 		job.error = err
@@ -411,8 +415,10 @@ func (dm *downloadManager) downloadFile(job *downloadJob) {
 		done <- err
 	}()
 
+	copied := false
 	select {
 	case err := <-done:
+		copied = true
 		if err != nil {
 			job.hasError = true
 			job.error = err
@@ -422,6 +428,19 @@ func (dm *downloadManager) downloadFile(job *downloadJob) {
 	case <-job.cancelChan:
 		job.hasError = true
 		job.error = fmt.Errorf("download canceled")
+	case <-ctx.Done():
+		job.hasError = true
+		job.error = ctx.Err()
+	}
+
+	if job.hasError {
+		// Stop the copy before removing the partial file
+		cancel()
+		if !copied {
+			<-done
+		}
+		out.Close()
+		os.Remove(filePath)
 	}
 }
 
@@ -590,7 +609,7 @@ func (dm *downloadManager) render(renderer *sdl.Renderer) {
 		footerHelpItems = append(footerHelpItems, FooterHelpItem{ButtonName: "X", HelpText: speedToggleText})
 	}
 
-	renderFooter(renderer, internal.Fonts.SmallFont, footerHelpItems, 20, true)
+	RenderFooter(renderer, internal.Fonts.SmallFont, footerHelpItems, 20, true)
 }
 
 func (dm *downloadManager) renderMultipleDownloads(renderer *sdl.Renderer, windowWidth int32, contentAreaStart int32, contentAreaHeight int32, filenameHeight int32, spacingBetweenFilenameAndBar int32, spacingBetweenDownloads int32, singleDownloadHeight int32) {
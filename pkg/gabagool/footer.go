@@ -15,7 +15,9 @@ type FooterHelpItem struct {
 	ButtonName string
 }
 
-func renderFooter(
+// RenderFooter draws the button hint pills along the bottom of the screen.
+// When transparentBackground is false the footer area is blanked first.
+func RenderFooter(
 	renderer *sdl.Renderer,
 	font *ttf.Font,
 	footerHelpItems []FooterHelpItem,
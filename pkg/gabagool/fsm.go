@@ -1,8 +1,12 @@
 package gabagool
 
 import (
+	"context"
 	"fmt"
 	"reflect"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
 )
 
 // ExitCode represents the result of a screen draw operation
@@ -20,14 +24,32 @@ const (
 
 type StateName string
 
-// Context holds shared state between screens during FSM execution
+// Context holds shared state between screens during FSM execution.
+// Values are keyed by type (Set/Get), by name (SetNamed/GetNamed) and by the state that returned them (GetResult).
 type Context struct {
-	data map[reflect.Type]any
+	data    map[reflect.Type]any
+	named   map[string]any
+	results map[StateName]any
+	runCtx  context.Context
 }
 
 // NewContext creates a new context
 func NewContext() *Context {
-	return &Context{data: make(map[reflect.Type]any)}
+	return &Context{
+		data:    make(map[reflect.Type]any),
+		named:   make(map[string]any),
+		results: make(map[StateName]any),
+	}
+}
+
+// Context returns the context.Context of the running FSM. It is cancelled when the app quits,
+// the device powers off or the deadline given to RunContext passes.
+// Pass it to ProcessMessage and DownloadManager so they stop with the FSM.
+func (c *Context) Context() context.Context {
+	if c.runCtx == nil {
+		return context.Background()
+	}
+	return c.runCtx
 }
 
 // Set stores a value in the context by its type
@@ -73,7 +95,7 @@ func (n *stateNode[T]) name() StateName {
 
 func (n *stateNode[T]) execute(ctx *Context) (ExitCode, error) {
 	value, exitCode := n.fn(ctx)
-	Set(ctx, value) // Auto-store by type
+	setResult(ctx, n.nodeName, value) // Auto-store by type and state
 	return exitCode, nil
 }
 
@@ -92,28 +114,52 @@ func (n *actionNode) execute(ctx *Context) (ExitCode, error) {
 	return exitCode, nil
 }
 
+// DefaultHistoryDepth is the number of states the back-stack remembers unless changed with HistoryDepth
+const DefaultHistoryDepth = 32
+
+// TransitionKind controls what a transition does with the back-stack
+type TransitionKind int
+
+// TransitionGoto moves to the target state, TransitionPush also remembers the current state
+// and TransitionPop returns to the most recently pushed state.
+const (
+	TransitionGoto TransitionKind = iota
+	TransitionPush
+	TransitionPop
+)
+
 // transition defines a state transition
 type transition struct {
 	from StateName
 	code ExitCode
 	to   StateName
+	kind TransitionKind
 	hook func(*Context) error
 }
 
 // FSM represents the finite state machine
 type FSM struct {
-	nodes       map[StateName]node
-	transitions []transition
-	initialNode StateName
-	ctx         *Context
+	nodes        map[StateName]node
+	transitions  []transition
+	initialNode  StateName
+	ctx          *Context
+	history      []StateName
+	historyDepth int
+	popOnBack    bool
+	persistence  *fsmPersistence
+	hooks        fsmHooks
+
+	errorRoutes       map[StateName]StateName
+	defaultErrorRoute StateName
 }
 
 // NewFSM creates a new FSM
 func NewFSM() *FSM {
 	return &FSM{
-		nodes:       make(map[StateName]node),
-		transitions: []transition{},
-		ctx:         NewContext(),
+		nodes:        make(map[StateName]node),
+		transitions:  []transition{},
+		ctx:          NewContext(),
+		historyDepth: DefaultHistoryDepth,
 	}
 }
 
@@ -146,25 +192,120 @@ func (f *FSM) Context() *Context {
 	return f.ctx
 }
 
-// Run executes the FSM
+// HistoryDepth limits how many states the back-stack remembers; the oldest entries are dropped first.
+// A depth of zero or less disables the limit.
+func (f *FSM) HistoryDepth(depth int) *FSM {
+	f.historyDepth = depth
+	return f
+}
+
+// PopOnBack makes ExitCodeBack return to the previously pushed state
+// for any state that has no explicit ExitCodeBack transition.
+func (f *FSM) PopOnBack() *FSM {
+	f.popOnBack = true
+	return f
+}
+
+// History returns the back-stack, oldest state first
+func (f *FSM) History() []StateName {
+	history := make([]StateName, len(f.history))
+	copy(history, f.history)
+	return history
+}
+
+func (f *FSM) push(name StateName) {
+	f.history = append(f.history, name)
+	if f.historyDepth > 0 && len(f.history) > f.historyDepth {
+		f.history = f.history[len(f.history)-f.historyDepth:]
+	}
+}
+
+func (f *FSM) pop() (StateName, bool) {
+	if len(f.history) == 0 {
+		return "", false
+	}
+	name := f.history[len(f.history)-1]
+	f.history = f.history[:len(f.history)-1]
+	return name, true
+}
+
+// Run executes the FSM until a state exits it, the app quits or the device powers off
 func (f *FSM) Run() error {
+	return f.RunContext(context.Background())
+}
+
+// RunWithDeadline executes the FSM, stopping before the next state once the deadline has passed
+func (f *FSM) RunWithDeadline(deadline time.Time) error {
+	ctx, cancel := context.WithDeadline(context.Background(), deadline)
+	defer cancel()
+	return f.RunContext(ctx)
+}
+
+// RunContext executes the FSM with a context that is also cancelled when the app quits or the device powers off.
+// States read it with Context.Context. Once it is cancelled Run stops before the next state and returns its error,
+// keeping any persisted state so the next Run resumes where it stopped.
+func (f *FSM) RunContext(ctx context.Context) error {
 	if f.initialNode == "" {
-		return fmt.Errorf("no initial state set")
+		return ErrNoInitialState
 	}
 
-	currentNode := f.initialNode
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(internal.AppContext(), cancel)
+	defer stop()
+
+	f.ctx.runCtx = ctx
+
+	f.history = nil
+	currentNode := f.resume(f.initialNode)
+
+	_, err := f.run(currentNode)
+	if err == nil {
+		f.clearPersisted()
+	}
+	return err
+}
+
+// run executes from currentNode and returns the exit code that ended the machine
+func (f *FSM) run(currentNode StateName) (ExitCode, error) {
+	defer f.closeEvents()
+
+	// States that failed since the last state that succeeded, to stop error routes that lead back to them
+	failing := make(map[StateName]bool)
 
 	for {
+		if err := f.ctx.Context().Err(); err != nil {
+			return ExitCodeCancel, fmt.Errorf("stopped before state %s: %w", currentNode, err)
+		}
+
 		node, exists := f.nodes[currentNode]
 		if !exists {
-			return fmt.Errorf("state not found: %s", currentNode)
+			return ExitCodeError, fmt.Errorf("state not found: %s", currentNode)
 		}
 
-		exitCode, err := node.execute(f.ctx)
+		started := time.Now()
+		exitCode, err := f.execute(node)
 		if err != nil {
-			return fmt.Errorf("error in state %s: %w", currentNode, err)
+			to, routed := f.errorRoute(currentNode)
+			if !routed {
+				return ExitCodeError, fmt.Errorf("error in state %s: %w", currentNode, err)
+			}
+
+			failing[currentNode] = true
+			if failing[to] {
+				return ExitCodeError, fmt.Errorf("error in state %s: %w: %w", currentNode, ErrErrorRouteLoop, err)
+			}
+
+			Set(f.ctx, StateError{State: currentNode, Err: err})
+			f.emit(TransitionEvent{From: currentNode, To: to, Code: ExitCodeError, Duration: time.Since(started)})
+
+			currentNode = to
+			f.persist(currentNode)
+			continue
 		}
 
+		clear(failing)
+
 		var matched *transition
 		for i := range f.transitions {
 			t := &f.transitions[i]
@@ -174,21 +315,43 @@ func (f *FSM) Run() error {
 			}
 		}
 
+		if matched == nil && exitCode == ExitCodeBack && f.popOnBack {
+			matched = &transition{from: currentNode, code: exitCode, kind: TransitionPop}
+		}
+
 		if matched == nil {
-			return nil
+			f.emit(TransitionEvent{From: currentNode, Code: exitCode, Duration: time.Since(started)})
+			return exitCode, nil
 		}
 
 		if matched.hook != nil {
 			if err := matched.hook(f.ctx); err != nil {
-				return fmt.Errorf("transition hook error: %w", err)
+				return ExitCodeError, fmt.Errorf("transition hook error: %w", err)
 			}
 		}
 
-		if matched.to == "" {
-			return nil
+		next := matched.to
+		switch matched.kind {
+		case TransitionPush:
+			f.push(currentNode)
+		case TransitionPop:
+			next, _ = f.pop()
+		}
+
+		f.emit(TransitionEvent{
+			From:     currentNode,
+			To:       next,
+			Code:     exitCode,
+			Kind:     matched.kind,
+			Duration: time.Since(started),
+		})
+
+		if next == "" {
+			return exitCode, nil
 		}
 
-		currentNode = matched.to
+		currentNode = next
+		f.persist(currentNode)
 	}
 }
 
@@ -219,6 +382,28 @@ func (b *StateBuilder) OnWithHook(code ExitCode, to StateName, hook func(*Contex
 	return b
 }
 
+// Push defines a transition that remembers the current state on the back-stack
+func (b *StateBuilder) Push(code ExitCode, to StateName) *StateBuilder {
+	b.fsm.transitions = append(b.fsm.transitions, transition{
+		from: b.state,
+		code: code,
+		to:   to,
+		kind: TransitionPush,
+	})
+	return b
+}
+
+// Pop defines a transition back to the most recently pushed state.
+// If the back-stack is empty the FSM exits.
+func (b *StateBuilder) Pop(code ExitCode) *StateBuilder {
+	b.fsm.transitions = append(b.fsm.transitions, transition{
+		from: b.state,
+		code: code,
+		kind: TransitionPop,
+	})
+	return b
+}
+
 // Exit defines a terminal transition
 func (b *StateBuilder) Exit(code ExitCode) *StateBuilder {
 	return b.On(code, "")
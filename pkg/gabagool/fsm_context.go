@@ -0,0 +1,112 @@
+package gabagool
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+)
+
+// ContextEntryKind tells how a Context entry is keyed
+type ContextEntryKind string
+
+const (
+	ContextEntryType   ContextEntryKind = "type"
+	ContextEntryNamed  ContextEntryKind = "named"
+	ContextEntryResult ContextEntryKind = "result"
+)
+
+// ContextEntry describes a single value held by a Context
+type ContextEntry struct {
+	Kind  ContextEntryKind
+	Key   string // Type name, value name or state name depending on Kind
+	Value any
+}
+
+// SetNamed stores a value in the context under a name, independent of its type
+func SetNamed[T any](c *Context, key string, value T) {
+	c.named[key] = value
+}
+
+// GetNamed retrieves a value stored with SetNamed
+func GetNamed[T any](c *Context, key string) (T, bool) {
+	val, ok := c.named[key]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	typed, ok := val.(T)
+	return typed, ok
+}
+
+// MustGetNamed retrieves a named value or panics
+func MustGetNamed[T any](c *Context, key string) T {
+	val, ok := GetNamed[T](c, key)
+	if !ok {
+		panic(fmt.Sprintf("%T named %q not found in context", *new(T), key))
+	}
+	return val
+}
+
+// GetResult retrieves the last value returned by a state, even if another state
+// has since stored a value of the same type
+func GetResult[T any](c *Context, state StateName) (T, bool) {
+	val, ok := c.results[state]
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	typed, ok := val.(T)
+	return typed, ok
+}
+
+// Delete removes the value stored by type
+func Delete[T any](c *Context) {
+	delete(c.data, reflect.TypeOf((*T)(nil)).Elem())
+}
+
+// DeleteNamed removes a value stored with SetNamed
+func (c *Context) DeleteNamed(key string) {
+	delete(c.named, key)
+}
+
+// ClearResult forgets the last value returned by a state
+func (c *Context) ClearResult(state StateName) {
+	delete(c.results, state)
+}
+
+// Clear removes every value from the context
+func (c *Context) Clear() {
+	clear(c.data)
+	clear(c.named)
+	clear(c.results)
+}
+
+// Entries lists everything held by the context, sorted by kind and key
+func (c *Context) Entries() []ContextEntry {
+	entries := make([]ContextEntry, 0, len(c.data)+len(c.named)+len(c.results))
+
+	for t, value := range c.data {
+		entries = append(entries, ContextEntry{Kind: ContextEntryType, Key: t.String(), Value: value})
+	}
+	for key, value := range c.named {
+		entries = append(entries, ContextEntry{Kind: ContextEntryNamed, Key: key, Value: value})
+	}
+	for state, value := range c.results {
+		entries = append(entries, ContextEntry{Kind: ContextEntryResult, Key: string(state), Value: value})
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Kind != entries[j].Kind {
+			return entries[i].Kind < entries[j].Kind
+		}
+		return entries[i].Key < entries[j].Key
+	})
+
+	return entries
+}
+
+// setResult stores a state's return value by type and under the state's name
+func setResult[T any](c *Context, state StateName, value T) {
+	Set(c, value)
+	c.results[state] = value
+}
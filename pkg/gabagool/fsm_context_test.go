@@ -0,0 +1,130 @@
+package gabagool
+
+import (
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+func TestContextNamedValues(t *testing.T) {
+	ctx := NewContext()
+	SetNamed(ctx, "query", "mario")
+	SetNamed(ctx, "page", 3)
+	Set(ctx, "typed")
+
+	tests := []struct {
+		name   string
+		get    func() (any, bool)
+		want   any
+		wantOK bool
+	}{
+		{name: "string", get: func() (any, bool) { return GetNamed[string](ctx, "query") }, want: "mario", wantOK: true},
+		{name: "int", get: func() (any, bool) { return GetNamed[int](ctx, "page") }, want: 3, wantOK: true},
+		{name: "wrong type", get: func() (any, bool) { return GetNamed[int](ctx, "query") }, want: 0},
+		{name: "missing", get: func() (any, bool) { return GetNamed[string](ctx, "platform") }, want: ""},
+		{name: "named values do not replace typed ones", get: func() (any, bool) { return Get[string](ctx) }, want: "typed", wantOK: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.get()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("got %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("delete", func(t *testing.T) {
+		ctx.DeleteNamed("query")
+		if _, ok := GetNamed[string](ctx, "query"); ok {
+			t.Error("value still present after DeleteNamed")
+		}
+	})
+
+	t.Run("must get panics when missing", func(t *testing.T) {
+		defer func() {
+			if recover() == nil {
+				t.Error("MustGetNamed did not panic")
+			}
+		}()
+		MustGetNamed[string](ctx, "query")
+	})
+}
+
+func TestContextResults(t *testing.T) {
+	fsm := NewFSM()
+	AddState(fsm, "first", func(*Context) (string, ExitCode) { return "one", ExitCodeSuccess }).On(ExitCodeSuccess, "second")
+	AddState(fsm, "second", func(*Context) (string, ExitCode) { return "two", ExitCodeQuit }).Exit(ExitCodeQuit)
+	fsm.Start("first")
+	if err := fsm.Run(); err != nil {
+		t.Fatalf("Run() returned %v", err)
+	}
+	ctx := fsm.Context()
+
+	if got, _ := Get[string](ctx); got != "two" {
+		t.Errorf("Get = %q, want the latest result", got)
+	}
+	for state, want := range map[StateName]string{"first": "one", "second": "two"} {
+		if got, ok := GetResult[string](ctx, state); !ok || got != want {
+			t.Errorf("GetResult(%s) = %q, %v, want %q", state, got, ok, want)
+		}
+	}
+
+	ctx.ClearResult("first")
+	if _, ok := GetResult[string](ctx, "first"); ok {
+		t.Error("result still present after ClearResult")
+	}
+	if _, ok := GetResult[string](ctx, "second"); !ok {
+		t.Error("ClearResult removed the result of another state")
+	}
+}
+
+func TestContextEntriesAndClear(t *testing.T) {
+	ctx := NewContext()
+	Set(ctx, 7)
+	SetNamed(ctx, "b", true)
+	SetNamed(ctx, "a", false)
+	setResult(ctx, "state", "value")
+
+	var got []string
+	for _, entry := range ctx.Entries() {
+		got = append(got, string(entry.Kind)+":"+entry.Key)
+	}
+	want := []string{"named:a", "named:b", "result:state", "type:int", "type:string"}
+	if !slices.Equal(got, want) {
+		t.Errorf("Entries() = %q, want %q", got, want)
+	}
+
+	Delete[int](ctx)
+	if _, ok := Get[int](ctx); ok {
+		t.Error("value still present after Delete")
+	}
+
+	ctx.Clear()
+	if entries := ctx.Entries(); len(entries) != 0 {
+		t.Errorf("Entries() after Clear = %v", entries)
+	}
+}
+
+func TestContextResultsNotPersisted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fsm.json")
+
+	saved := newPersistTestFSM(path)
+	setResult(saved.Context(), "games", persistTestSelection{Platform: "GBA", Page: 2})
+	SetNamed(saved.Context(), "unregistered", "dropped")
+	saved.persist("details")
+
+	restored := newPersistTestFSM(path)
+	restored.resume("platforms")
+	ctx := restored.Context()
+
+	if got, ok := Get[persistTestSelection](ctx); !ok || got.Platform != "GBA" {
+		t.Errorf("registered type stored by a state was not restored: %+v, %v", got, ok)
+	}
+	if _, ok := GetResult[persistTestSelection](ctx, "games"); ok {
+		t.Error("per-state results are restored, want them to start empty")
+	}
+	if _, ok := GetNamed[string](ctx, "unregistered"); ok {
+		t.Error("a named value that was not registered was restored")
+	}
+}
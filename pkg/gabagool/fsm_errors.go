@@ -0,0 +1,129 @@
+package gabagool
+
+import (
+	"fmt"
+	"runtime/debug"
+)
+
+// StateError is stored in the Context when a state fails and the FSM routes to an error state.
+// The error state can read it with Get[StateError].
+type StateError struct {
+	State StateName
+	Err   error
+}
+
+func (e StateError) Error() string {
+	return fmt.Sprintf("error in state %s: %v", e.State, e.Err)
+}
+
+func (e StateError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is returned in place of a panic raised inside a state
+type PanicError struct {
+	Value any
+	Stack []byte
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// stateNodeE wraps a function that returns a typed value or an error
+type stateNodeE[T any] struct {
+	nodeName StateName
+	fn       func(*Context) (T, ExitCode, error)
+}
+
+func (n *stateNodeE[T]) name() StateName {
+	return n.nodeName
+}
+
+func (n *stateNodeE[T]) execute(ctx *Context) (ExitCode, error) {
+	value, exitCode, err := n.fn(ctx)
+	if err != nil {
+		return exitCode, err
+	}
+	setResult(ctx, n.nodeName, value) // Auto-store by type and state
+	return exitCode, nil
+}
+
+// actionNodeE wraps a function that returns an exit code or an error
+type actionNodeE struct {
+	nodeName StateName
+	fn       func(*Context) (ExitCode, error)
+}
+
+func (n *actionNodeE) name() StateName {
+	return n.nodeName
+}
+
+func (n *actionNodeE) execute(ctx *Context) (ExitCode, error) {
+	return n.fn(ctx)
+}
+
+// AddStateE adds a state that returns a typed value or an error.
+// The value is only stored in the context when the error is nil.
+func AddStateE[T any](fsm *FSM, name StateName, fn func(*Context) (T, ExitCode, error)) *StateBuilder {
+	fsm.nodes[name] = &stateNodeE[T]{
+		nodeName: name,
+		fn:       fn,
+	}
+	return &StateBuilder{fsm: fsm, state: name}
+}
+
+// AddActionE adds an action that returns an exit code or an error
+func AddActionE(fsm *FSM, name StateName, fn func(*Context) (ExitCode, error)) *StateBuilder {
+	fsm.nodes[name] = &actionNodeE{
+		nodeName: name,
+		fn:       fn,
+	}
+	return &StateBuilder{fsm: fsm, state: name}
+}
+
+// OnError routes any state that fails (returns an error or panics) without its own OnError to the given state.
+// Without a route the error is returned from Run. A route that leads back to a state that has failed
+// since the last state succeeded stops Run with ErrErrorRouteLoop instead of retrying forever.
+func (f *FSM) OnError(to StateName) *FSM {
+	f.defaultErrorRoute = to
+	return f
+}
+
+// OnError routes this state to the given state when it returns an error or panics
+func (b *StateBuilder) OnError(to StateName) *StateBuilder {
+	if b.fsm.errorRoutes == nil {
+		b.fsm.errorRoutes = make(map[StateName]StateName)
+	}
+	b.fsm.errorRoutes[b.state] = to
+	return b
+}
+
+func (f *FSM) errorRoute(state StateName) (StateName, bool) {
+	if to, ok := f.errorRoutes[state]; ok {
+		return to, true
+	}
+	if f.defaultErrorRoute != "" && f.defaultErrorRoute != state {
+		return f.defaultErrorRoute, true
+	}
+	return "", false
+}
+
+// errorTransitions returns the error routes as transitions so they can be validated and exported
+func (f *FSM) errorTransitions() []transition {
+	var transitions []transition
+	for _, name := range f.stateNames() {
+		if to, ok := f.errorRoute(name); ok {
+			transitions = append(transitions, transition{from: name, code: ExitCodeError, to: to})
+		}
+	}
+	return transitions
+}
+
+// recoverState turns a panic inside a state into a PanicError
+func recoverState(exitCode *ExitCode, err *error) {
+	if r := recover(); r != nil {
+		*exitCode = ExitCodeError
+		*err = &PanicError{Value: r, Stack: debug.Stack()}
+	}
+}
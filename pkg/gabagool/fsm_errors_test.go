@@ -0,0 +1,98 @@
+package gabagool
+
+import (
+	"errors"
+	"slices"
+	"testing"
+)
+
+var errTestFailure = errors.New("load failed")
+
+func TestFSMExitHooksRunOnFailure(t *testing.T) {
+	tests := []struct {
+		name  string
+		state func(*Context) (ExitCode, error)
+	}{
+		{name: "error", state: func(*Context) (ExitCode, error) { return ExitCodeSuccess, errTestFailure }},
+		{name: "panic", state: func(*Context) (ExitCode, error) { panic("boom") }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var codes []ExitCode
+			fsm := NewFSM()
+			AddActionE(fsm, "load", tt.state).
+				OnExit(func(_ *Context, code ExitCode) error {
+					codes = append(codes, code)
+					return nil
+				})
+			fsm.Start("load")
+
+			if err := fsm.Run(); err == nil {
+				t.Fatal("Run() returned nil for a failing state")
+			}
+			if !slices.Equal(codes, []ExitCode{ExitCodeError}) {
+				t.Errorf("exit hooks saw %v, want [Error]", codes)
+			}
+		})
+	}
+}
+
+func TestFSMErrorRouteLoops(t *testing.T) {
+	fail := func(*Context) (ExitCode, error) { return ExitCodeSuccess, errTestFailure }
+
+	tests := []struct {
+		name    string
+		build   func(fsm *FSM)
+		wantRun error
+	}{
+		{
+			name: "route to itself",
+			build: func(fsm *FSM) {
+				AddActionE(fsm, "load", fail).OnError("load")
+			},
+			wantRun: ErrErrorRouteLoop,
+		},
+		{
+			name: "routes between two failing states",
+			build: func(fsm *FSM) {
+				AddActionE(fsm, "load", fail).OnError("fallback")
+				AddActionE(fsm, "fallback", fail).OnError("load")
+			},
+			wantRun: ErrErrorRouteLoop,
+		},
+		{
+			name: "error state that succeeds may retry",
+			build: func(fsm *FSM) {
+				attempts := 0
+				AddActionE(fsm, "load", func(*Context) (ExitCode, error) {
+					attempts++
+					if attempts < 3 {
+						return ExitCodeSuccess, errTestFailure
+					}
+					return ExitCodeQuit, nil
+				}).OnError("retry").Exit(ExitCodeQuit)
+				AddAction(fsm, "retry", func(*Context) ExitCode { return ExitCodeSuccess }).On(ExitCodeSuccess, "load")
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsm := NewFSM()
+			tt.build(fsm)
+			fsm.Start("load")
+
+			err := fsm.Run()
+			if tt.wantRun == nil && err != nil {
+				t.Fatalf("Run() returned %v", err)
+			}
+			if tt.wantRun != nil && !errors.Is(err, tt.wantRun) {
+				t.Fatalf("Run() returned %v, want %v", err, tt.wantRun)
+			}
+			if tt.wantRun != nil && !errors.Is(fsm.Validate(), ErrErrorRouteLoop) {
+				t.Errorf("Validate() did not report the loop")
+			}
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package gabagool
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Ids of the DOT pseudo nodes
+const (
+	dotStart = "__start"
+	dotExit  = "__exit"
+	dotBack  = "__back"
+)
+
+// ExportDOT renders the state graph in Graphviz DOT format.
+// Push transitions are bold, pop transitions are dashed edges to a "(previous)" node,
+// and exits point to a terminal node. The pseudo nodes use ids starting with "__"
+// so states named start, exit or back keep their own nodes.
+func (f *FSM) ExportDOT() string {
+	var b strings.Builder
+
+	b.WriteString("digraph FSM {\n")
+	b.WriteString("\trankdir=LR;\n")
+	fmt.Fprintf(&b, "\t%q [shape=point, label=\"\"];\n", dotStart)
+	fmt.Fprintf(&b, "\t%q [shape=doublecircle, label=\"\"];\n", dotExit)
+
+	if f.hasPopTransitions() {
+		fmt.Fprintf(&b, "\t%q [shape=plaintext, label=\"(previous)\"];\n", dotBack)
+	}
+
+	for _, name := range f.stateNames() {
+		fmt.Fprintf(&b, "\t%q [shape=box];\n", name)
+	}
+
+	if f.initialNode != "" {
+		fmt.Fprintf(&b, "\t%q -> %q;\n", dotStart, f.initialNode)
+	}
+
+	for _, t := range f.graphTransitions() {
+		label := exitCodeLabel(t.code)
+		switch t.kind {
+		case TransitionPush:
+			fmt.Fprintf(&b, "\t%q -> %q [label=%q, style=bold];\n", t.from, t.to, label+" (push)")
+		case TransitionPop:
+			fmt.Fprintf(&b, "\t%q -> %q [label=%q, style=dashed];\n", t.from, dotBack, label)
+		default:
+			to := string(t.to)
+			if to == "" {
+				to = dotExit
+			}
+			fmt.Fprintf(&b, "\t%q -> %q [label=%q];\n", t.from, to, label)
+		}
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// ExportMermaid renders the state graph as a Mermaid stateDiagram-v2.
+// Pop transitions point to a "(previous)" pseudo state.
+func (f *FSM) ExportMermaid() string {
+	var b strings.Builder
+
+	ids := make(map[StateName]string)
+	b.WriteString("stateDiagram-v2\n")
+	for i, name := range f.stateNames() {
+		ids[name] = fmt.Sprintf("s%d", i)
+		fmt.Fprintf(&b, "    state %s as %s\n", mermaidQuote(string(name)), ids[name])
+	}
+
+	if f.hasPopTransitions() {
+		b.WriteString("    state \"(previous)\" as back\n")
+	}
+
+	id := func(name StateName) string {
+		if existing, ok := ids[name]; ok {
+			return existing
+		}
+		// Undefined targets still get drawn so Validate problems show up in the diagram
+		ids[name] = fmt.Sprintf("s%d", len(ids))
+		fmt.Fprintf(&b, "    state %s as %s\n", mermaidQuote(string(name)), ids[name])
+		return ids[name]
+	}
+
+	if f.initialNode != "" {
+		fmt.Fprintf(&b, "    [*] --> %s\n", id(f.initialNode))
+	}
+
+	for _, t := range f.graphTransitions() {
+		label := exitCodeLabel(t.code)
+		switch t.kind {
+		case TransitionPush:
+			fmt.Fprintf(&b, "    %s --> %s : %s (push)\n", id(t.from), id(t.to), label)
+		case TransitionPop:
+			fmt.Fprintf(&b, "    %s --> back : %s\n", id(t.from), label)
+		default:
+			if t.to == "" {
+				fmt.Fprintf(&b, "    %s --> [*] : %s\n", id(t.from), label)
+			} else {
+				fmt.Fprintf(&b, "    %s --> %s : %s\n", id(t.from), id(t.to), label)
+			}
+		}
+	}
+
+	return b.String()
+}
+
+// mermaidQuote quotes a state name for Mermaid, which does not understand Go escapes.
+// Quotes and hashes use Mermaid's entity codes and line breaks become spaces.
+func mermaidQuote(text string) string {
+	text = strings.NewReplacer(`"`, "#quot;", "#", "#35;", "\r\n", " ", "\n", " ", "\r", " ").Replace(text)
+	return `"` + text + `"`
+}
+
+// graphTransitions returns the explicit transitions plus the error routes and the implicit pops added by PopOnBack
+func (f *FSM) graphTransitions() []transition {
+	transitions := make([]transition, len(f.transitions))
+	copy(transitions, f.transitions)
+	transitions = append(transitions, f.errorTransitions()...)
+
+	if f.popOnBack {
+		for _, name := range f.stateNames() {
+			explicit := false
+			for _, t := range f.transitions {
+				if t.from == name && t.code == ExitCodeBack {
+					explicit = true
+					break
+				}
+			}
+			if !explicit {
+				transitions = append(transitions, transition{from: name, code: ExitCodeBack, kind: TransitionPop})
+			}
+		}
+	}
+
+	return transitions
+}
+
+func (f *FSM) hasPopTransitions() bool {
+	for _, t := range f.graphTransitions() {
+		if t.kind == TransitionPop {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package gabagool
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestMermaidQuote(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "Game List", want: `"Game List"`},
+		{name: "quotes", in: `Say "hi"`, want: `"Say #quot;hi#quot;"`},
+		{name: "hash", in: "Disc #2", want: `"Disc #35;2"`},
+		{name: "backslash", in: `C:\ROMS`, want: `"C:\ROMS"`},
+		{name: "line break", in: "two\nlines", want: `"two lines"`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := mermaidQuote(tt.in); got != tt.want {
+				t.Errorf("mermaidQuote(%q) = %s, want %s", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestExportMermaidEscapesStateNames(t *testing.T) {
+	fsm := NewFSM()
+	AddAction(fsm, `Pick "Favorite"`, func(*Context) ExitCode { return ExitCodeQuit }).Exit(ExitCodeQuit)
+	fsm.Start(`Pick "Favorite"`)
+
+	out := fsm.ExportMermaid()
+	if !strings.Contains(out, `state "Pick #quot;Favorite#quot;" as s0`) {
+		t.Errorf("state name not escaped for Mermaid:\n%s", out)
+	}
+	if strings.Contains(out, `\"`) {
+		t.Errorf("output contains Go escapes:\n%s", out)
+	}
+}
+
+func TestExportDOTReservedNames(t *testing.T) {
+	fsm := NewFSM()
+	AddAction(fsm, "exit", func(*Context) ExitCode { return ExitCodeSuccess }).On(ExitCodeSuccess, "back")
+	AddAction(fsm, "back", func(*Context) ExitCode { return ExitCodeQuit }).Exit(ExitCodeQuit)
+	fsm.Start("exit")
+
+	out := fsm.ExportDOT()
+	for _, line := range []string{
+		`"exit" [shape=box];`,
+		`"back" [shape=box];`,
+		`"__start" -> "exit";`,
+		`"exit" -> "back" [label=`,
+		`"back" -> "__exit" [label=`,
+	} {
+		if !strings.Contains(out, line) {
+			t.Errorf("output is missing %s:\n%s", line, out)
+		}
+	}
+	if strings.Contains(out, `"exit" [shape=doublecircle`) || strings.Contains(out, `-> "exit" [label=`) {
+		t.Errorf("the exit pseudo node collides with the exit state:\n%s", out)
+	}
+}
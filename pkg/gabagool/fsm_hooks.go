@@ -0,0 +1,130 @@
+package gabagool
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// NodeFunc runs a single state and returns its exit code
+type NodeFunc func(ctx *Context) (ExitCode, error)
+
+// Middleware wraps every state execution. Call next to run the state (and any inner middleware).
+type Middleware func(state StateName, next NodeFunc) NodeFunc
+
+// TransitionEvent describes a completed state and where the FSM went next.
+// To is empty when the state's exit code ended Run.
+type TransitionEvent struct {
+	From     StateName
+	To       StateName
+	Code     ExitCode
+	Kind     TransitionKind
+	Duration time.Duration // How long From was running
+}
+
+// fsmHooks holds the lifecycle hooks, middleware and event subscribers of an FSM
+type fsmHooks struct {
+	enter       map[StateName][]func(*Context) error
+	exit        map[StateName][]func(*Context, ExitCode) error
+	middleware  []Middleware
+	subscribers []func(TransitionEvent)
+	channels    []chan TransitionEvent
+}
+
+// Use adds middleware that wraps every state execution.
+// Middleware runs in the order added, the first one being the outermost.
+func (f *FSM) Use(middleware ...Middleware) *FSM {
+	f.hooks.middleware = append(f.hooks.middleware, middleware...)
+	return f
+}
+
+// OnTransition registers a callback that is called synchronously after every transition
+func (f *FSM) OnTransition(fn func(TransitionEvent)) *FSM {
+	f.hooks.subscribers = append(f.hooks.subscribers, fn)
+	return f
+}
+
+// Events returns a channel that receives every transition of the next Run.
+// Events are dropped rather than blocking the UI if the buffer is full.
+// The channel is closed when Run returns.
+func (f *FSM) Events(buffer int) <-chan TransitionEvent {
+	events := make(chan TransitionEvent, buffer)
+	f.hooks.channels = append(f.hooks.channels, events)
+	return events
+}
+
+// OnEnter registers a hook that runs before the state is executed
+func (b *StateBuilder) OnEnter(fn func(*Context) error) *StateBuilder {
+	if b.fsm.hooks.enter == nil {
+		b.fsm.hooks.enter = make(map[StateName][]func(*Context) error)
+	}
+	b.fsm.hooks.enter[b.state] = append(b.fsm.hooks.enter[b.state], fn)
+	return b
+}
+
+// OnExit registers a hook that runs after the state returns, before its transition is followed.
+// It also runs when the state returns an error or panics, with ExitCodeError.
+func (b *StateBuilder) OnExit(fn func(*Context, ExitCode) error) *StateBuilder {
+	if b.fsm.hooks.exit == nil {
+		b.fsm.hooks.exit = make(map[StateName][]func(*Context, ExitCode) error)
+	}
+	b.fsm.hooks.exit[b.state] = append(b.fsm.hooks.exit[b.state], fn)
+	return b
+}
+
+// execute runs a node with its enter/exit hooks and the FSM middleware.
+// A panic anywhere inside is recovered into a PanicError.
+func (f *FSM) execute(n node) (exitCode ExitCode, err error) {
+	defer recoverState(&exitCode, &err)
+
+	state := n.name()
+
+	for _, hook := range f.hooks.enter[state] {
+		if err := hook(f.ctx); err != nil {
+			return ExitCodeError, fmt.Errorf("enter hook error: %w", err)
+		}
+	}
+
+	run := NodeFunc(n.execute)
+	for i := len(f.hooks.middleware) - 1; i >= 0; i-- {
+		run = f.hooks.middleware[i](state, run)
+	}
+
+	func() {
+		// Recover here too so the exit hooks still run after a panic
+		defer recoverState(&exitCode, &err)
+		exitCode, err = run(f.ctx)
+	}()
+
+	hookCode := exitCode
+	if err != nil {
+		hookCode = ExitCodeError
+	}
+	for _, hook := range f.hooks.exit[state] {
+		if hookErr := hook(f.ctx, hookCode); hookErr != nil {
+			return exitCode, errors.Join(err, fmt.Errorf("exit hook error: %w", hookErr))
+		}
+	}
+
+	return exitCode, err
+}
+
+func (f *FSM) emit(event TransitionEvent) {
+	for _, fn := range f.hooks.subscribers {
+		fn(event)
+	}
+
+	for _, events := range f.hooks.channels {
+		select {
+		case events <- event:
+		default:
+		}
+	}
+}
+
+func (f *FSM) closeEvents() {
+	for _, events := range f.hooks.channels {
+		close(events)
+	}
+	f.hooks.channels = nil
+}
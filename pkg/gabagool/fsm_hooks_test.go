@@ -0,0 +1,222 @@
+package gabagool
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"testing"
+)
+
+func TestFSMHookOrder(t *testing.T) {
+	type setup func(fsm *FSM, state *StateBuilder, log *[]string)
+
+	enter := func(name string, err error) setup {
+		return func(fsm *FSM, state *StateBuilder, log *[]string) {
+			state.OnEnter(func(*Context) error {
+				*log = append(*log, "enter "+name)
+				return err
+			})
+		}
+	}
+	exit := func(name string) setup {
+		return func(fsm *FSM, state *StateBuilder, log *[]string) {
+			state.OnExit(func(_ *Context, code ExitCode) error {
+				*log = append(*log, fmt.Sprintf("exit %s %d", name, code))
+				return nil
+			})
+		}
+	}
+	middleware := func(name string, callNext bool) setup {
+		return func(fsm *FSM, state *StateBuilder, log *[]string) {
+			fsm.Use(func(_ StateName, next NodeFunc) NodeFunc {
+				return func(ctx *Context) (ExitCode, error) {
+					*log = append(*log, "before "+name)
+					defer func() { *log = append(*log, "after "+name) }()
+					if !callNext {
+						return ExitCodeQuit, nil
+					}
+					return next(ctx)
+				}
+			})
+		}
+	}
+
+	quit := fmt.Sprintf("%d", ExitCodeQuit)
+	errorCode := fmt.Sprintf("%d", ExitCodeError)
+
+	tests := []struct {
+		name    string
+		setups  []setup
+		want    []string
+		wantErr bool
+	}{
+		{
+			name:   "enter, middleware, state, exit",
+			setups: []setup{enter("a", nil), exit("a"), middleware("m", true)},
+			want:   []string{"enter a", "before m", "state", "after m", "exit a " + quit},
+		},
+		{
+			name:   "hooks run in the order added",
+			setups: []setup{enter("a", nil), enter("b", nil), exit("a"), exit("b")},
+			want:   []string{"enter a", "enter b", "state", "exit a " + quit, "exit b " + quit},
+		},
+		{
+			name:   "first middleware is outermost",
+			setups: []setup{middleware("outer", true), middleware("inner", true)},
+			want:   []string{"before outer", "before inner", "state", "after inner", "after outer"},
+		},
+		{
+			name:   "middleware can skip the state",
+			setups: []setup{middleware("outer", true), middleware("guard", false), exit("a")},
+			want:   []string{"before outer", "before guard", "after guard", "after outer", "exit a " + quit},
+		},
+		{
+			name:    "failing enter hook skips the state and exit hooks",
+			setups:  []setup{enter("a", errTestFailure), enter("b", nil), middleware("m", true), exit("a")},
+			want:    []string{"enter a"},
+			wantErr: true,
+		},
+		{
+			name: "exit hooks see a middleware error",
+			setups: []setup{exit("a"), func(fsm *FSM, _ *StateBuilder, _ *[]string) {
+				fsm.Use(func(StateName, NodeFunc) NodeFunc {
+					return func(*Context) (ExitCode, error) { return ExitCodeSuccess, errTestFailure }
+				})
+			}},
+			want:    []string{"exit a " + errorCode},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var log []string
+			fsm := NewFSM()
+			state := AddAction(fsm, "only", func(*Context) ExitCode {
+				log = append(log, "state")
+				return ExitCodeQuit
+			}).Exit(ExitCodeQuit)
+			for _, setup := range tt.setups {
+				setup(fsm, state, &log)
+			}
+			fsm.Start("only")
+
+			err := fsm.Run()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Run() returned %v, want error: %v", err, tt.wantErr)
+			}
+			if tt.wantErr && !errors.Is(err, errTestFailure) {
+				t.Errorf("Run() returned %v, want it to wrap %v", err, errTestFailure)
+			}
+			if !slices.Equal(log, tt.want) {
+				t.Errorf("calls %q, want %q", log, tt.want)
+			}
+		})
+	}
+}
+
+func TestFSMTransitionEvents(t *testing.T) {
+	const (
+		browse  StateName = "browse"
+		details StateName = "details"
+		report  StateName = "report"
+	)
+
+	sequence := func(codes ...ExitCode) func(*Context) ExitCode {
+		return func(*Context) ExitCode {
+			code := codes[0]
+			codes = codes[1:]
+			return code
+		}
+	}
+
+	tests := []struct {
+		name  string
+		build func(fsm *FSM)
+		want  []TransitionEvent
+	}{
+		{
+			name: "push, pop and exit",
+			build: func(fsm *FSM) {
+				AddAction(fsm, browse, sequence(ExitCodeSuccess, ExitCodeQuit)).
+					Push(ExitCodeSuccess, details).
+					Exit(ExitCodeQuit)
+				AddAction(fsm, details, sequence(ExitCodeBack)).Pop(ExitCodeBack)
+			},
+			want: []TransitionEvent{
+				{From: browse, To: details, Code: ExitCodeSuccess, Kind: TransitionPush},
+				{From: details, To: browse, Code: ExitCodeBack, Kind: TransitionPop},
+				{From: browse, Code: ExitCodeQuit},
+			},
+		},
+		{
+			name: "pop with an empty stack exits",
+			build: func(fsm *FSM) {
+				AddAction(fsm, browse, sequence(ExitCodeBack)).Pop(ExitCodeBack)
+			},
+			want: []TransitionEvent{
+				{From: browse, Code: ExitCodeBack, Kind: TransitionPop},
+			},
+		},
+		{
+			name: "goto and unmatched exit code",
+			build: func(fsm *FSM) {
+				AddAction(fsm, browse, sequence(ExitCodeAction)).On(ExitCodeAction, details)
+				AddAction(fsm, details, sequence(ExitCodeCancel))
+			},
+			want: []TransitionEvent{
+				{From: browse, To: details, Code: ExitCodeAction, Kind: TransitionGoto},
+				{From: details, Code: ExitCodeCancel},
+			},
+		},
+		{
+			name: "error route",
+			build: func(fsm *FSM) {
+				AddActionE(fsm, browse, func(*Context) (ExitCode, error) { return ExitCodeSuccess, errTestFailure }).
+					OnError(report)
+				AddAction(fsm, report, sequence(ExitCodeQuit)).Exit(ExitCodeQuit)
+			},
+			want: []TransitionEvent{
+				{From: browse, To: report, Code: ExitCodeError},
+				{From: report, Code: ExitCodeQuit},
+			},
+		},
+	}
+
+	withoutDuration := func(events []TransitionEvent) []TransitionEvent {
+		result := make([]TransitionEvent, len(events))
+		for i, event := range events {
+			event.Duration = 0
+			result[i] = event
+		}
+		return result
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsm := NewFSM()
+			tt.build(fsm)
+			fsm.Start(browse)
+
+			var subscribed []TransitionEvent
+			fsm.OnTransition(func(event TransitionEvent) { subscribed = append(subscribed, event) })
+			channel := fsm.Events(len(tt.want))
+
+			if err := fsm.Run(); err != nil {
+				t.Fatalf("Run() returned %v", err)
+			}
+
+			var received []TransitionEvent
+			for event := range channel {
+				received = append(received, event)
+			}
+
+			if got := withoutDuration(subscribed); !slices.Equal(got, tt.want) {
+				t.Errorf("OnTransition saw %+v, want %+v", got, tt.want)
+			}
+			if got := withoutDuration(received); !slices.Equal(got, tt.want) {
+				t.Errorf("Events received %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package gabagool
+
+import "fmt"
+
+// ContextScope controls which Context a nested machine runs with
+type ContextScope int
+
+const (
+	// ContextShared runs the nested machine on the parent's Context, so everything it stores is visible afterwards
+	ContextShared ContextScope = iota
+	// ContextScoped runs the nested machine on a copy of the parent's Context, discarding its values when it exits
+	ContextScoped
+)
+
+// MachineOptions configures a nested machine added with AddMachine
+type MachineOptions struct {
+	Scope ContextScope
+	// ExitCodes maps the nested machine's final exit code to the exit code seen by the parent.
+	// Codes that aren't mapped are passed through unchanged.
+	ExitCodes map[ExitCode]ExitCode
+}
+
+// machineNode runs a complete FSM as a single state of its parent
+type machineNode struct {
+	nodeName StateName
+	machine  *FSM
+	options  MachineOptions
+}
+
+func (n *machineNode) name() StateName {
+	return n.nodeName
+}
+
+func (n *machineNode) execute(ctx *Context) (ExitCode, error) {
+	if n.machine.initialNode == "" {
+		return ExitCodeError, ErrNoInitialState
+	}
+
+	childCtx := ctx
+	if n.options.Scope == ContextScoped {
+		childCtx = ctx.clone()
+	}
+
+	ownCtx := n.machine.ctx
+	n.machine.ctx = childCtx
+	defer func() {
+		n.machine.ctx = ownCtx
+	}()
+
+	n.machine.history = nil
+	exitCode, err := n.machine.run(n.machine.initialNode)
+	if err != nil {
+		return exitCode, err
+	}
+
+	if mapped, ok := n.options.ExitCodes[exitCode]; ok {
+		return mapped, nil
+	}
+	return exitCode, nil
+}
+
+// AddMachine adds a complete FSM as a single state. The nested machine runs from its start state
+// until one of its exit codes ends it; that exit code (after MachineOptions.ExitCodes) selects the
+// parent's transition. The nested machine's current position is not persisted with the parent.
+func AddMachine(fsm *FSM, name StateName, machine *FSM, options MachineOptions) *StateBuilder {
+	fsm.nodes[name] = &machineNode{
+		nodeName: name,
+		machine:  machine,
+		options:  options,
+	}
+	return &StateBuilder{fsm: fsm, state: name}
+}
+
+// validateMachines validates every nested machine, prefixing problems with the state that holds it
+func (f *FSM) validateMachines() []error {
+	var problems []error
+	for _, name := range f.stateNames() {
+		if n, ok := f.nodes[name].(*machineNode); ok {
+			if err := n.machine.validate(false); err != nil {
+				problems = append(problems, fmt.Errorf("in machine %s: %w", name, err))
+			}
+		}
+	}
+	return problems
+}
+
+// clone returns a shallow copy of the context
+func (c *Context) clone() *Context {
+	clone := NewContext()
+	clone.runCtx = c.runCtx
+	for key, value := range c.data {
+		clone.data[key] = value
+	}
+	for key, value := range c.named {
+		clone.named[key] = value
+	}
+	for state, value := range c.results {
+		clone.results[state] = value
+	}
+	return clone
+}
@@ -0,0 +1,163 @@
+package gabagool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFSMMachineExitCodes(t *testing.T) {
+	tests := []struct {
+		name      string
+		childCode ExitCode
+		exitCodes map[ExitCode]ExitCode
+		want      StateName
+	}{
+		{name: "passed through", childCode: ExitCodeSuccess, want: "done"},
+		{name: "other code passed through", childCode: ExitCodeCancel, want: "cancelled"},
+		{name: "mapped", childCode: ExitCodeAction, exitCodes: map[ExitCode]ExitCode{ExitCodeAction: ExitCodeCancel}, want: "cancelled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child := NewFSM()
+			AddAction(child, "pick", func(*Context) ExitCode { return tt.childCode })
+			child.Start("pick")
+
+			var reached StateName
+			parent := NewFSM()
+			AddMachine(parent, "wizard", child, MachineOptions{ExitCodes: tt.exitCodes}).
+				On(ExitCodeSuccess, "done").
+				On(ExitCodeCancel, "cancelled")
+			for _, name := range []StateName{"done", "cancelled"} {
+				AddAction(parent, name, func(*Context) ExitCode {
+					reached = name
+					return ExitCodeQuit
+				}).Exit(ExitCodeQuit)
+			}
+			parent.Start("wizard")
+
+			if err := parent.Run(); err != nil {
+				t.Fatalf("Run() returned %v", err)
+			}
+			if reached != tt.want {
+				t.Errorf("parent reached %q, want %q", reached, tt.want)
+			}
+		})
+	}
+}
+
+func TestFSMMachineContextScope(t *testing.T) {
+	type counter struct{ n int }
+
+	tests := []struct {
+		name        string
+		scope       ContextScope
+		wantVisible bool
+	}{
+		{name: "shared", scope: ContextShared, wantVisible: true},
+		{name: "scoped", scope: ContextScoped, wantVisible: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			child := NewFSM()
+			AddAction(child, "edit", func(ctx *Context) ExitCode {
+				SetNamed(ctx, "child", "value")
+				Set(ctx, 2)
+				MustGet[*counter](ctx).n++
+				return ExitCodeSuccess
+			})
+			child.Start("edit")
+
+			parent := NewFSM()
+			AddMachine(parent, "nested", child, MachineOptions{Scope: tt.scope})
+			parent.Start("nested")
+			shared := &counter{}
+			Set(parent.Context(), shared)
+			Set(parent.Context(), 1)
+
+			if err := parent.Run(); err != nil {
+				t.Fatalf("Run() returned %v", err)
+			}
+
+			ctx := parent.Context()
+			if _, ok := GetNamed[string](ctx, "child"); ok != tt.wantVisible {
+				t.Errorf("child's named value visible to the parent: %v, want %v", ok, tt.wantVisible)
+			}
+			if got, _ := Get[int](ctx); (got == 2) != tt.wantVisible {
+				t.Errorf("parent's int is %d after the child replaced it", got)
+			}
+			// The scoped copy is shallow, so values behind pointers are still shared
+			if shared.n != 1 {
+				t.Errorf("counter = %d, want the child's change through the shared pointer", shared.n)
+			}
+		})
+	}
+}
+
+func TestFSMMachineClosesEvents(t *testing.T) {
+	child := NewFSM()
+	AddAction(child, "step", func(*Context) ExitCode { return ExitCodeSuccess })
+	child.Start("step")
+	events := child.Events(4)
+
+	var closedDuringParent bool
+	parent := NewFSM()
+	AddMachine(parent, "nested", child, MachineOptions{}).On(ExitCodeSuccess, "after")
+	AddAction(parent, "after", func(*Context) ExitCode {
+		select {
+		case _, ok := <-events:
+			// Drain the buffered event, then the channel must already be closed
+			if ok {
+				_, ok = <-events
+			}
+			closedDuringParent = !ok
+		default:
+		}
+		return ExitCodeQuit
+	}).Exit(ExitCodeQuit)
+	parent.Start("nested")
+
+	if err := parent.Run(); err != nil {
+		t.Fatalf("Run() returned %v", err)
+	}
+	if !closedDuringParent {
+		t.Error("the nested machine's events were not closed when it finished")
+	}
+	if len(child.hooks.channels) != 0 {
+		t.Error("the nested machine kept its event channels for the next run")
+	}
+}
+
+func TestFSMMachineRunsFromStartEachTime(t *testing.T) {
+	var visited []StateName
+	child := NewFSM()
+	AddAction(child, "first", func(*Context) ExitCode {
+		visited = append(visited, "first")
+		return ExitCodeSuccess
+	}).Push(ExitCodeSuccess, "second")
+	AddAction(child, "second", func(*Context) ExitCode {
+		visited = append(visited, "second")
+		return ExitCodeSuccess
+	})
+	child.Start("first")
+
+	runs := 0
+	parent := NewFSM()
+	AddMachine(parent, "nested", child, MachineOptions{}).On(ExitCodeSuccess, "again")
+	AddAction(parent, "again", func(*Context) ExitCode {
+		runs++
+		if runs < 2 {
+			return ExitCodeSuccess
+		}
+		return ExitCodeQuit
+	}).On(ExitCodeSuccess, "nested").Exit(ExitCodeQuit)
+	parent.Start("nested")
+
+	if err := parent.Run(); err != nil {
+		t.Fatalf("Run() returned %v", err)
+	}
+	if want := []StateName{"first", "second", "first", "second"}; !slices.Equal(visited, want) {
+		t.Errorf("visited %v, want %v", visited, want)
+	}
+}
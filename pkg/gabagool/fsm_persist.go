@@ -0,0 +1,203 @@
+package gabagool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+// fsmPersistence holds the file the FSM is saved to and the context values that are saved with it
+type fsmPersistence struct {
+	path   string
+	values map[string]persistentValue
+}
+
+// persistentValue knows how to move one registered context type in and out of JSON
+type persistentValue struct {
+	save func(ctx *Context) (json.RawMessage, bool, error)
+	load func(ctx *Context, data json.RawMessage) error
+}
+
+// persistedFSM is the on-disk representation of a running FSM
+type persistedFSM struct {
+	State   StateName                  `json:"state"`
+	History []StateName                `json:"history,omitempty"`
+	Values  map[string]json.RawMessage `json:"values,omitempty"`
+}
+
+// PersistTo saves the current state, back-stack and registered context values to path after every transition.
+// The next Run resumes from the saved state. The file is removed when Run finishes without an error.
+// Per-state results read with GetResult are not saved; a registered type is restored by type only.
+func (f *FSM) PersistTo(path string) *FSM {
+	if f.persistence == nil {
+		f.persistence = &fsmPersistence{values: make(map[string]persistentValue)}
+	}
+	f.persistence.path = path
+	return f
+}
+
+// RegisterPersistent marks a context type to be saved with the FSM under key.
+// The type must round-trip through encoding/json. The key should stay stable between releases.
+func RegisterPersistent[T any](fsm *FSM, key string) {
+	if fsm.persistence == nil {
+		fsm.persistence = &fsmPersistence{values: make(map[string]persistentValue)}
+	}
+
+	fsm.persistence.values[key] = persistentValue{
+		save: func(ctx *Context) (json.RawMessage, bool, error) {
+			value, ok := Get[T](ctx)
+			if !ok {
+				return nil, false, nil
+			}
+			data, err := json.Marshal(value)
+			return data, true, err
+		},
+		load: func(ctx *Context, data json.RawMessage) error {
+			var value T
+			if err := json.Unmarshal(data, &value); err != nil {
+				return err
+			}
+			Set(ctx, value)
+			return nil
+		},
+	}
+}
+
+// RegisterPersistentNamed marks a value stored with SetNamed to be saved with the FSM.
+// The type must round-trip through encoding/json.
+func RegisterPersistentNamed[T any](fsm *FSM, key string) {
+	if fsm.persistence == nil {
+		fsm.persistence = &fsmPersistence{values: make(map[string]persistentValue)}
+	}
+
+	fsm.persistence.values["named:"+key] = persistentValue{
+		save: func(ctx *Context) (json.RawMessage, bool, error) {
+			value, ok := GetNamed[T](ctx, key)
+			if !ok {
+				return nil, false, nil
+			}
+			data, err := json.Marshal(value)
+			return data, true, err
+		},
+		load: func(ctx *Context, data json.RawMessage) error {
+			var value T
+			if err := json.Unmarshal(data, &value); err != nil {
+				return err
+			}
+			SetNamed(ctx, key, value)
+			return nil
+		},
+	}
+}
+
+// resume restores a previously persisted FSM and returns the state to start from
+func (f *FSM) resume(initial StateName) StateName {
+	if f.persistence == nil || f.persistence.path == "" {
+		return initial
+	}
+
+	saved, err := loadPersistedFSM(f.persistence.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			internal.GetInternalLogger().Error("Failed to load persisted FSM", "path", f.persistence.path, "error", err)
+		}
+		return initial
+	}
+
+	if _, exists := f.nodes[saved.State]; !exists {
+		internal.GetInternalLogger().Warn("Persisted FSM state no longer exists", "state", saved.State)
+		return initial
+	}
+
+	for key, data := range saved.Values {
+		value, registered := f.persistence.values[key]
+		if !registered {
+			continue
+		}
+		if err := value.load(f.ctx, data); err != nil {
+			internal.GetInternalLogger().Error("Failed to restore persisted context value", "key", key, "error", err)
+		}
+	}
+
+	for _, name := range saved.History {
+		if _, exists := f.nodes[name]; exists {
+			f.push(name)
+		}
+	}
+
+	return saved.State
+}
+
+// persist writes the current state to disk. Failures are logged so a full SD card never stops the UI.
+func (f *FSM) persist(current StateName) {
+	if f.persistence == nil || f.persistence.path == "" {
+		return
+	}
+
+	saved := persistedFSM{
+		State:   current,
+		History: f.history,
+		Values:  make(map[string]json.RawMessage),
+	}
+
+	for key, value := range f.persistence.values {
+		data, ok, err := value.save(f.ctx)
+		if err != nil {
+			internal.GetInternalLogger().Error("Failed to persist context value", "key", key, "error", err)
+			continue
+		}
+		if ok {
+			saved.Values[key] = data
+		}
+	}
+
+	if err := saved.saveToJSON(f.persistence.path); err != nil {
+		internal.GetInternalLogger().Error("Failed to persist FSM", "path", f.persistence.path, "error", err)
+	}
+}
+
+func (f *FSM) clearPersisted() {
+	if f.persistence == nil || f.persistence.path == "" {
+		return
+	}
+
+	if err := os.Remove(f.persistence.path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		internal.GetInternalLogger().Error("Failed to remove persisted FSM", "path", f.persistence.path, "error", err)
+	}
+}
+
+func loadPersistedFSM(filePath string) (*persistedFSM, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON file: %w", err)
+	}
+
+	var saved persistedFSM
+	if err := json.Unmarshal(data, &saved); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return &saved, nil
+}
+
+// saveToJSON writes to a temporary file first so a power cut never leaves a half written file behind
+func (p *persistedFSM) saveToJSON(filePath string) error {
+	data, err := json.MarshalIndent(p, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal FSM to JSON: %w", err)
+	}
+
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to replace JSON file: %w", err)
+	}
+
+	return nil
+}
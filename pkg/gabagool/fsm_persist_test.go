@@ -0,0 +1,134 @@
+package gabagool
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+type persistTestSelection struct {
+	Platform string
+	Page     int
+}
+
+func newPersistTestFSM(path string) *FSM {
+	fsm := NewFSM()
+	for _, name := range []StateName{"platforms", "games", "details"} {
+		AddAction(fsm, name, func(*Context) ExitCode { return ExitCodeQuit }).Exit(ExitCodeQuit)
+	}
+	fsm.Start("platforms").PersistTo(path)
+	RegisterPersistent[persistTestSelection](fsm, "selection")
+	RegisterPersistentNamed[string](fsm, "query")
+	return fsm
+}
+
+func TestFSMPersistRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fsm.json")
+
+	saved := newPersistTestFSM(path)
+	Set(saved.Context(), persistTestSelection{Platform: "SNES", Page: 3})
+	SetNamed(saved.Context(), "query", "mario")
+	saved.push("platforms")
+	saved.push("games")
+	saved.persist("details")
+
+	tests := []struct {
+		name  string
+		check func(t *testing.T, fsm *FSM, state StateName)
+	}{
+		{
+			name: "state",
+			check: func(t *testing.T, fsm *FSM, state StateName) {
+				if state != "details" {
+					t.Errorf("resumed at %q, want details", state)
+				}
+			},
+		},
+		{
+			name: "history",
+			check: func(t *testing.T, fsm *FSM, state StateName) {
+				if got := fsm.History(); !slices.Equal(got, []StateName{"platforms", "games"}) {
+					t.Errorf("History() = %v", got)
+				}
+			},
+		},
+		{
+			name: "typed value",
+			check: func(t *testing.T, fsm *FSM, state StateName) {
+				got, ok := Get[persistTestSelection](fsm.Context())
+				if !ok || got != (persistTestSelection{Platform: "SNES", Page: 3}) {
+					t.Errorf("Get = %+v, %v", got, ok)
+				}
+			},
+		},
+		{
+			name: "named value",
+			check: func(t *testing.T, fsm *FSM, state StateName) {
+				if got, ok := GetNamed[string](fsm.Context(), "query"); !ok || got != "mario" {
+					t.Errorf("GetNamed = %q, %v", got, ok)
+				}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			restored := newPersistTestFSM(path)
+			tt.check(t, restored, restored.resume("platforms"))
+		})
+	}
+}
+
+func TestFSMResumeFallsBack(t *testing.T) {
+	tests := []struct {
+		name     string
+		contents string
+	}{
+		{name: "missing file"},
+		{name: "corrupt file", contents: "{not json"},
+		{name: "unknown state", contents: `{"state": "removed"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "fsm.json")
+			if tt.contents != "" {
+				if err := os.WriteFile(path, []byte(tt.contents), 0644); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			fsm := newPersistTestFSM(path)
+			if state := fsm.resume("platforms"); state != "platforms" {
+				t.Errorf("resumed at %q, want the initial state", state)
+			}
+		})
+	}
+}
+
+func TestFSMPersistClearedAfterRun(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "fsm.json")
+
+	fsm := newPersistTestFSM(path)
+	fsm.persist("games")
+	if err := fsm.Run(); err != nil {
+		t.Fatalf("Run() returned %v", err)
+	}
+	if _, err := os.Stat(path); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("persisted file still exists after a clean run: %v", err)
+	}
+
+	// A cancelled run keeps the file so the next Run resumes
+	fsm.persist("games")
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := fsm.RunContext(ctx); err == nil {
+		t.Fatal("RunContext with a cancelled context returned nil")
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("persisted file removed after a cancelled run: %v", err)
+	}
+}
@@ -0,0 +1,112 @@
+package gabagool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestFSMHistoryDepth(t *testing.T) {
+	tests := []struct {
+		name   string
+		depth  int
+		pushes []StateName
+		want   []StateName
+	}{
+		{name: "unlimited", depth: 0, pushes: []StateName{"a", "b", "c"}, want: []StateName{"a", "b", "c"}},
+		{name: "within depth", depth: 3, pushes: []StateName{"a", "b"}, want: []StateName{"a", "b"}},
+		{name: "oldest dropped", depth: 2, pushes: []StateName{"a", "b", "c", "d"}, want: []StateName{"c", "d"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fsm := NewFSM().HistoryDepth(tt.depth)
+			for _, name := range tt.pushes {
+				fsm.push(name)
+			}
+			if got := fsm.History(); !slices.Equal(got, tt.want) {
+				t.Errorf("History() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFSMPushPop(t *testing.T) {
+	const (
+		browse  StateName = "browse"
+		details StateName = "details"
+		confirm StateName = "confirm"
+	)
+
+	tests := []struct {
+		name      string
+		popOnBack bool
+		codes     map[StateName][]ExitCode
+		want      []StateName
+	}{
+		{
+			name: "pop returns to the pushing state",
+			codes: map[StateName][]ExitCode{
+				browse:  {ExitCodeSuccess, ExitCodeQuit},
+				details: {ExitCodeAction, ExitCodeCancel},
+				confirm: {ExitCodeCancel},
+			},
+			want: []StateName{browse, details, confirm, details, browse},
+		},
+		{
+			name:      "back pops without an explicit transition",
+			popOnBack: true,
+			codes: map[StateName][]ExitCode{
+				browse:  {ExitCodeSuccess, ExitCodeQuit},
+				details: {ExitCodeBack},
+			},
+			want: []StateName{browse, details, browse},
+		},
+		{
+			name: "pop with an empty stack exits",
+			codes: map[StateName][]ExitCode{
+				browse: {ExitCodeCancel},
+			},
+			want: []StateName{browse},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var visited []StateName
+			state := func(name StateName) func(*Context) ExitCode {
+				return func(*Context) ExitCode {
+					visited = append(visited, name)
+					codes := tt.codes[name]
+					code := codes[0]
+					tt.codes[name] = codes[1:]
+					return code
+				}
+			}
+
+			fsm := NewFSM()
+			AddAction(fsm, browse, state(browse)).
+				Push(ExitCodeSuccess, details).
+				Pop(ExitCodeCancel).
+				Exit(ExitCodeQuit)
+			AddAction(fsm, details, state(details)).
+				Push(ExitCodeAction, confirm).
+				Pop(ExitCodeCancel)
+			AddAction(fsm, confirm, state(confirm)).
+				Pop(ExitCodeCancel)
+			if tt.popOnBack {
+				fsm.PopOnBack()
+			}
+			fsm.Start(browse)
+
+			if err := fsm.Run(); err != nil {
+				t.Fatalf("Run() returned %v", err)
+			}
+			if !slices.Equal(visited, tt.want) {
+				t.Errorf("visited %v, want %v", visited, tt.want)
+			}
+			if history := fsm.History(); len(history) != 0 {
+				t.Errorf("history left behind: %v", history)
+			}
+		})
+	}
+}
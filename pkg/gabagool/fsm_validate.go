@@ -0,0 +1,159 @@
+package gabagool
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+)
+
+var (
+	ErrNoInitialState   = errors.New("no initial state set")
+	ErrUndefinedState   = errors.New("transition to undefined state")
+	ErrUnreachableState = errors.New("unreachable state")
+	ErrMissingExit      = errors.New("missing exit")
+	ErrErrorRouteLoop   = errors.New("error route loop")
+)
+
+// Validate checks the state graph without running it.
+// It reports transitions to undefined states, states that can't be reached from the start state,
+// states that don't handle ExitCodeBack or ExitCodeCancel (which would silently end Run),
+// and error routes that lead back to the failing state.
+// All problems are joined into a single error, each wrapping one of the Err* values above.
+func (f *FSM) Validate() error {
+	return f.validate(true)
+}
+
+// validate skips the missing exit check for nested machines, whose unhandled exit codes return to the parent
+func (f *FSM) validate(requireExits bool) error {
+	var problems []error
+
+	if f.initialNode == "" {
+		problems = append(problems, ErrNoInitialState)
+	} else if _, exists := f.nodes[f.initialNode]; !exists {
+		problems = append(problems, fmt.Errorf("%w: start state %s", ErrUndefinedState, f.initialNode))
+	}
+
+	for _, t := range f.graphTransitions() {
+		if t.kind == TransitionPop || t.to == "" {
+			continue
+		}
+		if _, exists := f.nodes[t.to]; !exists {
+			problems = append(problems, fmt.Errorf("%w: %s on %s goes to %s", ErrUndefinedState, t.from, exitCodeLabel(t.code), t.to))
+		}
+	}
+
+	reachable := f.reachableStates()
+	for _, name := range f.stateNames() {
+		if !reachable[name] {
+			problems = append(problems, fmt.Errorf("%w: %s", ErrUnreachableState, name))
+		}
+
+		for _, code := range []ExitCode{ExitCodeBack, ExitCodeCancel} {
+			if requireExits && !f.handles(name, code) {
+				problems = append(problems, fmt.Errorf("%w: %s does not handle %s", ErrMissingExit, name, exitCodeLabel(code)))
+			}
+		}
+	}
+
+	for _, name := range f.stateNames() {
+		if f.errorRouteLoops(name) {
+			problems = append(problems, fmt.Errorf("%w: errors in %s are routed back to it", ErrErrorRouteLoop, name))
+		}
+	}
+
+	problems = append(problems, f.validateMachines()...)
+
+	return errors.Join(problems...)
+}
+
+// reachableStates walks the transitions from the start state.
+// Pop transitions only return to states that were already visited, so they add nothing.
+func (f *FSM) reachableStates() map[StateName]bool {
+	reachable := make(map[StateName]bool)
+	if _, exists := f.nodes[f.initialNode]; !exists {
+		return reachable
+	}
+
+	transitions := f.graphTransitions()
+	queue := []StateName{f.initialNode}
+	reachable[f.initialNode] = true
+
+	for len(queue) > 0 {
+		current := queue[0]
+		queue = queue[1:]
+
+		for _, t := range transitions {
+			if t.from != current || t.to == "" || reachable[t.to] {
+				continue
+			}
+			if _, exists := f.nodes[t.to]; !exists {
+				continue
+			}
+			reachable[t.to] = true
+			queue = append(queue, t.to)
+		}
+	}
+
+	return reachable
+}
+
+// errorRouteLoops reports whether following error routes from name leads back to name
+func (f *FSM) errorRouteLoops(name StateName) bool {
+	visited := map[StateName]bool{}
+	current := name
+	for !visited[current] {
+		visited[current] = true
+		to, routed := f.errorRoute(current)
+		if !routed {
+			return false
+		}
+		if to == name {
+			return true
+		}
+		current = to
+	}
+	return false
+}
+
+func (f *FSM) handles(name StateName, code ExitCode) bool {
+	if code == ExitCodeBack && f.popOnBack {
+		return true
+	}
+	for _, t := range f.transitions {
+		if t.from == name && t.code == code {
+			return true
+		}
+	}
+	return false
+}
+
+// stateNames returns the registered states in a stable order
+func (f *FSM) stateNames() []StateName {
+	names := make([]StateName, 0, len(f.nodes))
+	for name := range f.nodes {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
+
+func exitCodeLabel(code ExitCode) string {
+	switch code {
+	case ExitCodeSuccess:
+		return "Success"
+	case ExitCodeBack:
+		return "Back"
+	case ExitCodeCancel:
+		return "Cancel"
+	case ExitCodeQuit:
+		return "Quit"
+	case ExitCodeAction:
+		return "Action"
+	case ExitCodeError:
+		return "Error"
+	default:
+		return fmt.Sprintf("%d", code)
+	}
+}
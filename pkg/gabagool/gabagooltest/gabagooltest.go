@@ -0,0 +1,226 @@
+// Package gabagooltest provides golden-image snapshot testing for gabagool screens.
+//
+// Screens are rendered by the headless backend, driven with scripted button presses
+// and compared against PNG files stored in GoldenDir. Run the tests with -update, or with
+// GABAGOOL_UPDATE_GOLDEN=1, to rewrite the golden images after an intentional visual change.
+package gabagooltest
+
+import (
+	"flag"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+)
+
+// UpdateEnv is the environment variable that rewrites golden images when set to a true value
+const UpdateEnv = "GABAGOOL_UPDATE_GOLDEN"
+
+// GoldenDir is the directory golden images are read from and written to.
+var GoldenDir = "testdata"
+
+// Tolerance controls how different a frame may be from its golden image.
+// Channel is the largest per-channel difference for two pixels to be considered equal.
+// MaxPixels is the number of differing pixels allowed before the comparison fails.
+type Tolerance struct {
+	Channel   uint8
+	MaxPixels int
+}
+
+// DefaultTolerance absorbs anti-aliasing noise without hiding real layout changes.
+var DefaultTolerance = Tolerance{Channel: 2, MaxPixels: 0}
+
+// Snapshot describes a single golden-image comparison.
+// Name is the golden file name without the .png extension.
+// Presses are delivered in order, one per frame, before the frame is captured.
+// Tolerance overrides DefaultTolerance when set.
+type Snapshot struct {
+	Name      string
+	Presses   []constants.VirtualButton
+	Tolerance *Tolerance
+}
+
+// Main initializes a headless gabagool instance, runs the tests and tears it down.
+// Call it from TestMain. Headless is forced on; FontPath falls back to FALLBACK_FONT.
+// An -update flag is registered unless the test package already defines one, in which case that flag is used.
+func Main(m *testing.M, options gabagool.Options) {
+	if flag.Lookup("update") == nil {
+		flag.Bool("update", false, "rewrite golden images with the current render")
+	}
+	flag.Parse()
+
+	options.Headless = true
+	if options.FontPath == "" {
+		options.FontPath = os.Getenv("FALLBACK_FONT")
+	}
+	if options.FontPath == "" {
+		fmt.Fprintln(os.Stderr, "gabagooltest: Options.FontPath or FALLBACK_FONT must be set")
+		os.Exit(1)
+	}
+	if options.LogFilename == "" {
+		options.LogFilename = "gabagooltest.log"
+	}
+
+	gabagool.Init(options)
+	code := m.Run()
+	gabagool.Close()
+
+	os.Exit(code)
+}
+
+// AssertScreen runs show with the snapshot's scripted presses and compares the
+// rendered frame against the golden image. show should display a single component;
+// it is ended with a quit event once the frame has been captured.
+func AssertScreen(tb testing.TB, snapshot Snapshot, show func()) {
+	tb.Helper()
+
+	var frame *image.RGBA
+	var captureErr error
+
+	gabagool.ScriptButtonPress(snapshot.Presses...)
+	gabagool.ScriptCapture(func(f *image.RGBA, err error) {
+		frame, captureErr = f, err
+	})
+	gabagool.ScriptQuit()
+
+	show()
+	gabagool.ClearScript()
+
+	if captureErr != nil {
+		tb.Fatalf("failed to capture frame for %s: %v", snapshot.Name, captureErr)
+	}
+	if frame == nil {
+		tb.Fatalf("screen exited before the frame for %s was captured", snapshot.Name)
+	}
+
+	tolerance := DefaultTolerance
+	if snapshot.Tolerance != nil {
+		tolerance = *snapshot.Tolerance
+	}
+
+	CompareGolden(tb, snapshot.Name, frame, tolerance)
+}
+
+// AssertList renders a List with the given options and compares it against the golden image.
+func AssertList(tb testing.TB, snapshot Snapshot, options gabagool.ListOptions) {
+	tb.Helper()
+
+	AssertScreen(tb, snapshot, func() {
+		_, _ = gabagool.List(options)
+	})
+}
+
+// CompareGolden compares frame against GoldenDir/name.png, or rewrites it when updating is enabled.
+// On mismatch the actual frame and a diff mask are written next to the golden image.
+func CompareGolden(tb testing.TB, name string, frame *image.RGBA, tolerance Tolerance) {
+	tb.Helper()
+
+	goldenPath := filepath.Join(GoldenDir, name+".png")
+
+	if shouldUpdate() {
+		if err := writePNG(goldenPath, frame); err != nil {
+			tb.Fatalf("failed to update golden image %s: %v", goldenPath, err)
+		}
+		return
+	}
+
+	golden, err := readPNG(goldenPath)
+	if err != nil {
+		tb.Fatalf("failed to read golden image %s (run with -update to create it): %v", goldenPath, err)
+	}
+
+	if golden.Bounds().Size() != frame.Bounds().Size() {
+		tb.Fatalf("frame size %v does not match golden image %s size %v",
+			frame.Bounds().Size(), goldenPath, golden.Bounds().Size())
+	}
+
+	diff, count := diffImages(golden, frame, tolerance.Channel)
+	if count <= tolerance.MaxPixels {
+		return
+	}
+
+	actualPath := filepath.Join(GoldenDir, name+".actual.png")
+	diffPath := filepath.Join(GoldenDir, name+".diff.png")
+	_ = writePNG(actualPath, frame)
+	_ = writePNG(diffPath, diff)
+
+	tb.Errorf("%s differs from golden image in %d pixels (allowed %d); see %s and %s",
+		name, count, tolerance.MaxPixels, actualPath, diffPath)
+}
+
+// shouldUpdate reports whether UpdateEnv or a boolean -update flag asks for golden images to be rewritten
+func shouldUpdate() bool {
+	if update, err := strconv.ParseBool(os.Getenv(UpdateEnv)); err == nil && update {
+		return true
+	}
+
+	if f := flag.Lookup("update"); f != nil {
+		update, _ := strconv.ParseBool(f.Value.String())
+		return update
+	}
+	return false
+}
+
+func diffImages(golden image.Image, frame *image.RGBA, channelTolerance uint8) (*image.RGBA, int) {
+	bounds := frame.Bounds()
+	goldenOrigin := golden.Bounds().Min
+	diff := image.NewRGBA(bounds)
+	count := 0
+
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			want := color.RGBAModel.Convert(golden.At(goldenOrigin.X+x-bounds.Min.X, goldenOrigin.Y+y-bounds.Min.Y)).(color.RGBA)
+			got := frame.RGBAAt(x, y)
+
+			if channelDelta(want.R, got.R) > channelTolerance ||
+				channelDelta(want.G, got.G) > channelTolerance ||
+				channelDelta(want.B, got.B) > channelTolerance ||
+				channelDelta(want.A, got.A) > channelTolerance {
+				diff.SetRGBA(x, y, color.RGBA{R: 255, A: 255})
+				count++
+			} else {
+				diff.SetRGBA(x, y, color.RGBA{R: got.R / 4, G: got.G / 4, B: got.B / 4, A: 255})
+			}
+		}
+	}
+
+	return diff, count
+}
+
+func channelDelta(a, b uint8) uint8 {
+	if a > b {
+		return a - b
+	}
+	return b - a
+}
+
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	return png.Decode(file)
+}
+
+func writePNG(path string, img image.Image) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	return png.Encode(file, img)
+}
@@ -0,0 +1,124 @@
+package gabagooltest
+
+import (
+	"fmt"
+	"image"
+	"image/color"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// recordingTB captures failures so tests can check that a comparison fails
+type recordingTB struct {
+	testing.TB
+	errors []string
+}
+
+func (r *recordingTB) Errorf(format string, args ...any) {
+	r.errors = append(r.errors, fmt.Sprintf(format, args...))
+}
+
+func testFrame(fill color.RGBA) *image.RGBA {
+	frame := image.NewRGBA(image.Rect(0, 0, 8, 6))
+	for y := 0; y < 6; y++ {
+		for x := 0; x < 8; x++ {
+			frame.SetRGBA(x, y, fill)
+		}
+	}
+	return frame
+}
+
+func TestCompareGoldenRoundTrip(t *testing.T) {
+	t.Setenv(UpdateEnv, "")
+	previous := GoldenDir
+	GoldenDir = t.TempDir()
+	t.Cleanup(func() { GoldenDir = previous })
+
+	golden := testFrame(color.RGBA{R: 40, G: 80, B: 120, A: 255})
+	if err := writePNG(filepath.Join(GoldenDir, "frame.png"), golden); err != nil {
+		t.Fatalf("failed to write golden image: %v", err)
+	}
+
+	changed := testFrame(color.RGBA{R: 40, G: 80, B: 120, A: 255})
+	changed.SetRGBA(3, 2, color.RGBA{R: 255, A: 255})
+
+	tests := []struct {
+		name       string
+		frame      *image.RGBA
+		tolerance  Tolerance
+		wantErrors int
+	}{
+		{name: "identical frame", frame: golden, tolerance: DefaultTolerance},
+		{name: "changed pixel", frame: changed, tolerance: DefaultTolerance, wantErrors: 1},
+		{name: "changed pixel within MaxPixels", frame: changed, tolerance: Tolerance{MaxPixels: 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tb := &recordingTB{TB: t}
+			CompareGolden(tb, "frame", tt.frame, tt.tolerance)
+
+			if len(tb.errors) != tt.wantErrors {
+				t.Fatalf("got %d failures %q, want %d", len(tb.errors), tb.errors, tt.wantErrors)
+			}
+			if tt.wantErrors == 0 {
+				return
+			}
+			for _, suffix := range []string{".actual.png", ".diff.png"} {
+				if _, err := os.Stat(filepath.Join(GoldenDir, "frame"+suffix)); err != nil {
+					t.Errorf("expected %s to be written: %v", suffix, err)
+				}
+			}
+		})
+	}
+}
+
+func TestCompareGoldenUpdate(t *testing.T) {
+	t.Setenv(UpdateEnv, "1")
+	previous := GoldenDir
+	GoldenDir = t.TempDir()
+	t.Cleanup(func() { GoldenDir = previous })
+
+	frame := testFrame(color.RGBA{R: 200, G: 10, B: 10, A: 255})
+	CompareGolden(t, "updated", frame, DefaultTolerance)
+
+	written, err := readPNG(filepath.Join(GoldenDir, "updated.png"))
+	if err != nil {
+		t.Fatalf("golden image was not written: %v", err)
+	}
+	if _, count := diffImages(written, frame, 0); count != 0 {
+		t.Errorf("written golden image differs from the frame in %d pixels", count)
+	}
+}
+
+func TestDiffImages(t *testing.T) {
+	base := color.RGBA{R: 100, G: 100, B: 100, A: 255}
+
+	tests := []struct {
+		name      string
+		pixel     color.RGBA
+		tolerance uint8
+		want      int
+	}{
+		{name: "same", pixel: base, want: 0},
+		{name: "within tolerance", pixel: color.RGBA{R: 102, G: 98, B: 100, A: 255}, tolerance: 2, want: 0},
+		{name: "outside tolerance", pixel: color.RGBA{R: 103, G: 100, B: 100, A: 255}, tolerance: 2, want: 1},
+		{name: "alpha differs", pixel: color.RGBA{R: 100, G: 100, B: 100, A: 0}, tolerance: 2, want: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			frame := testFrame(base)
+			frame.SetRGBA(1, 1, tt.pixel)
+
+			diff, count := diffImages(testFrame(base), frame, tt.tolerance)
+			if count != tt.want {
+				t.Errorf("got %d differing pixels, want %d", count, tt.want)
+			}
+			if marked := diff.RGBAAt(1, 1) == (color.RGBA{R: 255, A: 255}); marked != (tt.want == 1) {
+				t.Errorf("diff mask marked the pixel: %v, want %v", marked, tt.want == 1)
+			}
+		})
+	}
+}
@@ -0,0 +1,421 @@
+package gabagool
+
+import (
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// GridOptions configures the Grid component. Each item's ImageFilename is its thumbnail and Text its caption.
+type GridOptions struct {
+	Title             string
+	Items             []MenuItem
+	SelectedIndex     int
+	VisibleStartIndex int // Index of the first item shown; rounded down to the start of its row
+	Columns           int
+	ImageAspectRatio  float32 // Thumbnail height divided by width
+
+	EnableAction      bool
+	EnableMultiSelect bool
+	EnableHelp        bool
+
+	StartInMultiSelectMode bool
+	DisableBackButton      bool
+
+	HelpTitle string
+	HelpText  []string
+
+	Margins         internal.Padding
+	CellSpacing     int32
+	FooterHelpItems []FooterHelpItem
+
+	InputDelay        time.Duration
+	MultiSelectButton constants.VirtualButton
+
+	EmptyMessage      string
+	EmptyMessageColor sdl.Color
+}
+
+func DefaultGridOptions(title string, items []MenuItem) GridOptions {
+	return GridOptions{
+		Title:             title,
+		Items:             items,
+		Columns:           4,
+		ImageAspectRatio:  1.4,
+		Margins:           internal.UniformPadding(20),
+		CellSpacing:       16,
+		InputDelay:        constants.DefaultInputDelay,
+		MultiSelectButton: constants.VirtualButtonSelect,
+		EmptyMessage:      "No items available",
+		EmptyMessageColor: sdl.Color{R: 255, G: 255, B: 255, A: 255},
+	}
+}
+
+type gridController struct {
+	Options       GridOptions
+	SelectedItems map[int]bool
+	MultiSelect   bool
+	visibleRows   int
+
+	result    ListResult
+	done      bool
+	cancelled bool
+}
+
+// Grid shows items as a matrix of thumbnails with captions, navigated with the D-pad in both directions.
+// A selects the focused item, or toggles it in multi-select mode where Start confirms. X triggers the
+// action when EnableAction is set. The result has the same meaning as the one returned by List.
+func Grid(options GridOptions) (*ListResult, error) {
+	if options.Columns <= 0 {
+		options.Columns = 4
+	}
+	if options.ImageAspectRatio <= 0 {
+		options.ImageAspectRatio = 1.4
+	}
+	if options.SelectedIndex < 0 || options.SelectedIndex >= len(options.Items) {
+		options.SelectedIndex = 0
+	}
+
+	gc := &gridController{
+		Options:       options,
+		SelectedItems: make(map[int]bool),
+		MultiSelect:   options.StartInMultiSelectMode,
+		result: ListResult{
+			Items:    options.Items,
+			Selected: []int{},
+			Action:   ListActionSelected,
+		},
+	}
+
+	for i := range gc.Options.Items {
+		if gc.Options.Items[i].Selected {
+			gc.SelectedItems[i] = true
+		}
+	}
+
+	gc.Options.VisibleStartIndex -= gc.Options.VisibleStartIndex % gc.Options.Columns
+	screenWidth, screenHeight, _ := internal.GetWindow().Renderer.GetOutputSize()
+	gc.visibleRows = gc.calculateVisibleRows(screenWidth, screenHeight)
+	gc.scrollTo(gc.Options.SelectedIndex)
+
+	RunScreen(gc, gc.screenOptions())
+
+	if gc.cancelled {
+		return nil, ErrCancelled
+	}
+
+	return &gc.result, nil
+}
+
+func (gc *gridController) screenOptions() ScreenOptions {
+	options := DefaultScreenOptions()
+	options.InputDelay = gc.Options.InputDelay
+	options.EnableHelp = gc.Options.EnableHelp
+	options.HelpTitle = gc.Options.HelpTitle
+	options.HelpText = gc.Options.HelpText
+	return options
+}
+
+func (gc *gridController) HandleInput(inputEvent *InputEvent) {
+	if !inputEvent.Pressed {
+		return
+	}
+
+	switch inputEvent.Button {
+	case constants.VirtualButtonUp:
+		gc.moveSelection(-gc.Options.Columns)
+	case constants.VirtualButtonDown:
+		gc.moveSelection(gc.Options.Columns)
+	case constants.VirtualButtonLeft:
+		gc.moveSelection(-1)
+	case constants.VirtualButtonRight:
+		gc.moveSelection(1)
+	default:
+		gc.handleActionButtons(inputEvent.Button)
+	}
+}
+
+func (gc *gridController) Update() {}
+
+func (gc *gridController) Render(renderer *sdl.Renderer) {
+	gc.render(renderer)
+}
+
+func (gc *gridController) Done() bool {
+	return gc.done
+}
+
+func (gc *gridController) HandleResize(width, height int32) {
+	gc.visibleRows = gc.calculateVisibleRows(width, height)
+	gc.scrollTo(gc.Options.SelectedIndex)
+}
+
+func (gc *gridController) handleActionButtons(button constants.VirtualButton) {
+	if len(gc.Options.Items) == 0 && button != constants.VirtualButtonB {
+		return
+	}
+
+	switch button {
+	case constants.VirtualButtonA:
+		if gc.MultiSelect {
+			gc.toggleSelection(gc.Options.SelectedIndex)
+		} else {
+			gc.finish(ListActionSelected, []int{gc.Options.SelectedIndex})
+		}
+	case constants.VirtualButtonB:
+		if !gc.Options.DisableBackButton {
+			gc.done = true
+			gc.cancelled = true
+		}
+	case constants.VirtualButtonX:
+		if gc.Options.EnableAction {
+			if gc.MultiSelect {
+				gc.finish(ListActionTriggered, gc.getSelectedItems())
+			} else {
+				gc.finish(ListActionTriggered, []int{gc.Options.SelectedIndex})
+			}
+		}
+	case constants.VirtualButtonStart:
+		if gc.MultiSelect {
+			gc.finish(ListActionSelected, gc.getSelectedItems())
+		}
+	}
+
+	if button == gc.Options.MultiSelectButton && gc.Options.EnableMultiSelect {
+		gc.toggleMultiSelect()
+	}
+}
+
+func (gc *gridController) finish(action ListAction, selected []int) {
+	gc.done = true
+	gc.result.Action = action
+	if len(selected) > 0 {
+		gc.result.Selected = selected
+		gc.result.VisiblePosition = selected[0] - gc.Options.VisibleStartIndex
+	}
+}
+
+func (gc *gridController) moveSelection(delta int) {
+	count := len(gc.Options.Items)
+	if count == 0 {
+		return
+	}
+
+	newIndex := gc.Options.SelectedIndex + delta
+	columns := gc.Options.Columns
+	lastRow := (count - 1) / columns
+
+	switch {
+	case delta == 1 && newIndex >= count:
+		newIndex = 0
+	case delta == -1 && newIndex < 0:
+		newIndex = count - 1
+	case delta == columns && newIndex >= count:
+		// Down from the row above a partial last row lands on its last item; from the last row it wraps to the top
+		if gc.Options.SelectedIndex/columns < lastRow {
+			newIndex = count - 1
+		} else {
+			newIndex = gc.Options.SelectedIndex % columns
+		}
+	case delta == -columns && newIndex < 0:
+		newIndex = min(lastRow*columns+gc.Options.SelectedIndex%columns, count-1)
+	}
+
+	gc.Options.SelectedIndex = newIndex
+	gc.scrollTo(newIndex)
+}
+
+// scrollTo keeps the row containing index on screen
+func (gc *gridController) scrollTo(index int) {
+	columns := gc.Options.Columns
+	row := index / columns
+	firstRow := gc.Options.VisibleStartIndex / columns
+
+	if row < firstRow {
+		firstRow = row
+	} else if row >= firstRow+gc.visibleRows {
+		firstRow = row - gc.visibleRows + 1
+	}
+
+	gc.Options.VisibleStartIndex = max(firstRow, 0) * columns
+}
+
+func (gc *gridController) toggleMultiSelect() {
+	gc.MultiSelect = !gc.MultiSelect
+	if !gc.MultiSelect {
+		for i := range gc.Options.Items {
+			gc.Options.Items[i].Selected = false
+		}
+		gc.SelectedItems = make(map[int]bool)
+	}
+}
+
+func (gc *gridController) toggleSelection(index int) {
+	if index < 0 || index >= len(gc.Options.Items) || gc.Options.Items[index].NotMultiSelectable {
+		return
+	}
+
+	gc.Options.Items[index].Selected = !gc.Options.Items[index].Selected
+	if gc.Options.Items[index].Selected {
+		gc.SelectedItems[index] = true
+	} else {
+		delete(gc.SelectedItems, index)
+	}
+}
+
+func (gc *gridController) getSelectedItems() []int {
+	var indices []int
+	for i := range gc.Options.Items {
+		if gc.SelectedItems[i] {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// cellSize returns the size of a cell and the height of the thumbnail within it
+func (gc *gridController) cellSize(screenWidth int32) (cellWidth, cellHeight, imageHeight int32) {
+	columns := int32(gc.Options.Columns)
+	availableWidth := screenWidth - gc.Options.Margins.Left - gc.Options.Margins.Right
+	cellWidth = (availableWidth - gc.Options.CellSpacing*(columns-1)) / columns
+
+	captionHeight := int32(internal.Fonts.TinyFont.Height()) + int32(float32(12)*internal.GetScaleFactor())
+	imageHeight = int32(float32(cellWidth) * gc.Options.ImageAspectRatio)
+	return cellWidth, imageHeight + captionHeight, imageHeight
+}
+
+func (gc *gridController) gridStartY() int32 {
+	startY := gc.Options.Margins.Top
+	if gc.Options.Title != "" {
+		startY += int32(internal.Fonts.LargeFont.Height()) + constants.DefaultTitleSpacing
+	}
+	return startY
+}
+
+func (gc *gridController) calculateVisibleRows(screenWidth, screenHeight int32) int {
+	_, cellHeight, _ := gc.cellSize(screenWidth)
+	footerHeight := int32(float32(50)*internal.GetScaleFactor()) + gc.Options.Margins.Bottom
+
+	availableHeight := screenHeight - gc.gridStartY() - footerHeight
+	rows := int((availableHeight + gc.Options.CellSpacing) / (cellHeight + gc.Options.CellSpacing))
+	return max(rows, 1)
+}
+
+func (gc *gridController) render(renderer *sdl.Renderer) {
+	window := internal.GetWindow()
+	window.RenderBackground()
+
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+	theme := internal.GetTheme()
+
+	if gc.Options.Title != "" {
+		gc.renderText(renderer, internal.Fonts.LargeFont, gc.Options.Title, theme.ListTextColor,
+			gc.Options.Margins.Left, gc.Options.Margins.Top, screenWidth-gc.Options.Margins.Left-gc.Options.Margins.Right)
+	}
+
+	if len(gc.Options.Items) == 0 {
+		RenderMultilineText(renderer, gc.Options.EmptyMessage, internal.Fonts.MediumFont, screenWidth*3/4,
+			screenWidth/2, screenHeight/2, gc.Options.EmptyMessageColor)
+	} else {
+		gc.renderCells(renderer, screenWidth)
+	}
+
+	RenderFooter(renderer, internal.Fonts.SmallFont, gc.Options.FooterHelpItems, gc.Options.Margins.Bottom, true)
+}
+
+func (gc *gridController) renderCells(renderer *sdl.Renderer, screenWidth int32) {
+	scaleFactor := internal.GetScaleFactor()
+	theme := internal.GetTheme()
+	cellWidth, cellHeight, imageHeight := gc.cellSize(screenWidth)
+	startY := gc.gridStartY()
+	padding := int32(float32(6) * scaleFactor)
+
+	end := min(gc.Options.VisibleStartIndex+gc.visibleRows*gc.Options.Columns, len(gc.Options.Items))
+	for index := gc.Options.VisibleStartIndex; index < end; index++ {
+		item := gc.Options.Items[index]
+		position := int32(index - gc.Options.VisibleStartIndex)
+		column := position % int32(gc.Options.Columns)
+		row := position / int32(gc.Options.Columns)
+
+		cell := sdl.Rect{
+			X: gc.Options.Margins.Left + column*(cellWidth+gc.Options.CellSpacing),
+			Y: startY + row*(cellHeight+gc.Options.CellSpacing),
+			W: cellWidth,
+			H: cellHeight,
+		}
+
+		focused := index == gc.Options.SelectedIndex
+		if focused {
+			internal.DrawRoundedRect(renderer, &cell, int32(float32(12)*scaleFactor), theme.MainColor)
+		}
+
+		imageRect := sdl.Rect{X: cell.X + padding, Y: cell.Y + padding, W: cell.W - padding*2, H: imageHeight - padding*2}
+		gc.renderThumbnail(renderer, item.ImageFilename, imageRect)
+
+		caption := item.Text
+		if gc.MultiSelect && !item.NotMultiSelectable {
+			if gc.SelectedItems[index] {
+				caption = "☑ " + caption
+			} else {
+				caption = "☐ " + caption
+			}
+		}
+
+		textColor := theme.ListTextColor
+		if focused {
+			textColor = theme.ListTextSelectedColor
+		}
+		gc.renderText(renderer, internal.Fonts.TinyFont, caption, textColor, cell.X+padding, cell.Y+imageHeight, cell.W-padding*2)
+	}
+}
+
+// renderThumbnail draws the image scaled to fit within rect, or an empty tile when it cannot be loaded
+func (gc *gridController) renderThumbnail(renderer *sdl.Renderer, filename string, rect sdl.Rect) {
+	texture, _ := internal.GetImageLoader().Texture(filename, rect.W, rect.H)
+	if texture == nil {
+		internal.DrawRoundedRect(renderer, &rect, int32(float32(8)*internal.GetScaleFactor()), sdl.Color{R: 40, G: 40, B: 40, A: 255})
+		return
+	}
+
+	_, _, textureWidth, textureHeight, _ := texture.Query()
+	if textureWidth == 0 || textureHeight == 0 {
+		return
+	}
+
+	// Use the smaller scale to maintain the aspect ratio
+	scale := min(float32(rect.W)/float32(textureWidth), float32(rect.H)/float32(textureHeight))
+	width := int32(float32(textureWidth) * scale)
+	height := int32(float32(textureHeight) * scale)
+
+	renderer.Copy(texture, nil, &sdl.Rect{
+		X: rect.X + (rect.W-width)/2,
+		Y: rect.Y + (rect.H-height)/2,
+		W: width,
+		H: height,
+	})
+}
+
+// renderText draws a single line of text at x, y, cut short with an ellipsis when wider than maxWidth
+func (gc *gridController) renderText(renderer *sdl.Renderer, font *ttf.Font, text string, color sdl.Color, x, y, maxWidth int32) {
+	text = internal.TruncateToWidth(font, text, maxWidth)
+	if text == "" {
+		return
+	}
+
+	surface, _ := font.RenderUTF8Blended(text, color)
+	if surface == nil {
+		return
+	}
+	defer surface.Free()
+
+	texture, _ := renderer.CreateTextureFromSurface(surface)
+	if texture == nil {
+		return
+	}
+	defer texture.Destroy()
+
+	renderer.Copy(texture, nil, &sdl.Rect{X: x, Y: y, W: surface.W, H: surface.H})
+}
@@ -0,0 +1,79 @@
+package gabagool
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+// InputEvent is a single virtual button press or release as seen by the components.
+type InputEvent = internal.Event
+
+// IsHeadless reports whether Init was called with Options.Headless.
+func IsHeadless() bool {
+	return internal.IsHeadless()
+}
+
+// ScriptInput queues synthetic input events. Once real input has been drained,
+// one scripted event is delivered per frame so every step is rendered.
+func ScriptInput(events ...InputEvent) {
+	internal.GetInputProcessor().QueueScriptedEvents(events...)
+}
+
+// ScriptButtonPress queues a press and release for each button in order.
+func ScriptButtonPress(buttons ...constants.VirtualButton) {
+	for _, button := range buttons {
+		ScriptInput(
+			InputEvent{Button: button, Pressed: true},
+			InputEvent{Button: button, Pressed: false},
+		)
+	}
+}
+
+// ScriptCapture queues a frame capture. The callback receives the frame
+// rendered after every previously scripted event has been handled.
+func ScriptCapture(fn func(frame *image.RGBA, err error)) {
+	internal.GetInputProcessor().QueueScriptedAction(func() {
+		fn(internal.CaptureFrame())
+	})
+}
+
+// ScriptQuit queues a quit event, which makes the active component return.
+func ScriptQuit() {
+	internal.GetInputProcessor().QueueScriptedQuit()
+}
+
+// ClearScript drops scripted input that has not been delivered yet,
+// for example when a component exits before the whole script was consumed.
+func ClearScript() {
+	internal.GetInputProcessor().ClearScript()
+}
+
+// CaptureFrame reads back the most recently rendered frame.
+func CaptureFrame() (*image.RGBA, error) {
+	return internal.CaptureFrame()
+}
+
+// SaveFramePNG writes the most recently rendered frame to a PNG file.
+func SaveFramePNG(path string) error {
+	frame, err := internal.CaptureFrame()
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("failed to create frame file: %w", err)
+	}
+	defer file.Close()
+
+	if err := png.Encode(file, frame); err != nil {
+		return fmt.Errorf("failed to encode frame: %w", err)
+	}
+
+	return nil
+}
@@ -1,13 +1,13 @@
 package gabagool
 
 import (
-	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
 
-type helpOverlay struct {
+// HelpOverlay is a full screen, scrollable list of help lines.
+type HelpOverlay struct {
 	Title           string
 	Lines           []string
 	ShowingHelp     bool
@@ -24,7 +24,8 @@ type helpOverlay struct {
 	ExitTextPadding int32
 }
 
-func newHelpOverlay(title string, lines []string) *helpOverlay {
+// NewHelpOverlay creates a hidden help overlay sized to the window.
+func NewHelpOverlay(title string, lines []string) *HelpOverlay {
 	window := internal.GetWindow()
 	width, height := window.Window.GetSize()
 
@@ -32,7 +33,7 @@ func newHelpOverlay(title string, lines []string) *helpOverlay {
 		title = "Help"
 	}
 
-	return &helpOverlay{
+	return &HelpOverlay{
 		Title:           title,
 		Lines:           lines,
 		ScrollOffset:    0,
@@ -49,7 +50,8 @@ func newHelpOverlay(title string, lines []string) *helpOverlay {
 	}
 }
 
-func (h *helpOverlay) render(renderer *sdl.Renderer, font *ttf.Font) {
+// Render draws the overlay when it is showing.
+func (h *HelpOverlay) Render(renderer *sdl.Renderer, font *ttf.Font) {
 	if !h.ShowingHelp {
 		return
 	}
@@ -171,7 +173,7 @@ func (h *helpOverlay) render(renderer *sdl.Renderer, font *ttf.Font) {
 	}
 }
 
-func (h *helpOverlay) calculateMaxScroll() {
+func (h *HelpOverlay) calculateMaxScroll() {
 	contentY := h.Padding + h.LineHeight*2
 	contentHeight := h.Height - contentY - h.Padding - h.LineHeight - h.ExitTextPadding*7
 
@@ -184,7 +186,8 @@ func (h *helpOverlay) calculateMaxScroll() {
 	}
 }
 
-func (h *helpOverlay) scroll(direction int) {
+// Scroll moves the content by one line per step, clamped to the content height.
+func (h *HelpOverlay) Scroll(direction int) {
 	if !h.ShowingHelp {
 		return
 	}
@@ -202,31 +205,8 @@ func (h *helpOverlay) scroll(direction int) {
 	h.ScrollOffset = newOffset
 }
 
-func (h *helpOverlay) handleInput(event interface{}) bool {
-	processor := internal.GetInputProcessor()
-	inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-
-	if inputEvent == nil || !inputEvent.Pressed {
-		return false
-	}
-
-	button := inputEvent.Button
-
-	switch button {
-	case constants.VirtualButtonUp:
-		h.scroll(-1)
-		return true
-	case constants.VirtualButtonDown:
-		h.scroll(1)
-		return true
-	case constants.VirtualButtonMenu, constants.VirtualButtonB:
-		return false
-	default:
-		return false
-	}
-}
-
-func (h *helpOverlay) toggle() {
+// Toggle shows or hides the overlay, resetting the scroll position when shown.
+func (h *HelpOverlay) Toggle() {
 	h.ShowingHelp = !h.ShowingHelp
 	if h.ShowingHelp {
 		h.ScrollOffset = 0
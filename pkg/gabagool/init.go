@@ -18,12 +18,22 @@ type Options struct {
 	IsNextUI             bool
 	ControllerConfigFile string
 	LogFilename          string
+
+	// Headless renders to an offscreen software target instead of a display.
+	// Input is supplied with ScriptInput and frames are read with CaptureFrame.
+	Headless       bool
+	HeadlessWidth  int32
+	HeadlessHeight int32
+	FontPath       string // Overrides the theme font, required when Headless is set
+
+	ListStateFile string // Where Lists with a StateID save their state; kept in memory only when empty
 }
 
 // Init initializes SDL and the UI
 // Must be called before any other UI functions!
 func Init(options Options) {
 	internal.SetFilename(options.LogFilename)
+	listStates.setPath(options.ListStateFile)
 
 	if os.Getenv("NITRATES") != "" || os.Getenv("INPUT_CAPTURE") != "" {
 		internal.SetInternalLogLevel(slog.LevelDebug)
@@ -56,7 +66,20 @@ func Init(options Options) {
 		internal.SetTheme(theme)
 	}
 
+	if options.FontPath != "" {
+		theme := internal.GetTheme()
+		theme.FontPath = options.FontPath
+		internal.SetTheme(theme)
+	}
+
+	if options.Headless {
+		internal.InitHeadless(options.WindowTitle, options.HeadlessWidth, options.HeadlessHeight)
+		initInputRecording()
+		return
+	}
+
 	internal.Init(options.WindowTitle, options.ShowBackground, pbc)
+	initInputRecording()
 
 	if os.Getenv("INPUT_CAPTURE") != "" {
 		mapping := InputLogger()
@@ -73,6 +96,8 @@ func Init(options Options) {
 // Close Tidies up SDL and the UI
 // Must be called after all UI functions!
 func Close() {
+	saveInputRecording()
+	internal.GetImageLoader().Destroy()
 	internal.SDLCleanup()
 }
 
@@ -0,0 +1,96 @@
+package gabagool
+
+import (
+	"os"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+const (
+	// InputRecordPathEnvVar records the whole input session to this JSON file. It is saved whenever
+	// a screen exits, when the app is cancelled by a quit or power-off, and on Close.
+	InputRecordPathEnvVar = "INPUT_RECORD_PATH"
+	// InputReplayPathEnvVar replays a recorded input session from this JSON file after Init.
+	InputReplayPathEnvVar = "INPUT_REPLAY_PATH"
+)
+
+// InputRecording is a captured input session that can be saved to and loaded from JSON.
+type InputRecording = internal.InputRecording
+
+// InjectInput feeds a synthetic event into the same pipeline as real input.
+// The event is delivered once delay has passed since the previously injected event.
+func InjectInput(event InputEvent, delay time.Duration) {
+	internal.GetInputProcessor().Inject(event, delay)
+}
+
+// StartInputRecording begins capturing every mapped input event.
+func StartInputRecording() {
+	internal.GetInputProcessor().StartRecording()
+}
+
+// StopInputRecording ends the active recording and returns it, or nil if none was active.
+func StopInputRecording() *InputRecording {
+	return internal.GetInputProcessor().StopRecording()
+}
+
+// ReplayInput injects a recorded session, preserving the timing between events.
+func ReplayInput(recording *InputRecording) {
+	internal.GetInputProcessor().Replay(recording)
+}
+
+// LoadInputRecording reads a recording previously written with InputRecording.SaveToJSON.
+func LoadInputRecording(filePath string) (*InputRecording, error) {
+	return internal.LoadInputRecordingFromJSON(filePath)
+}
+
+func initInputRecording() {
+	if path := os.Getenv(InputReplayPathEnvVar); path != "" {
+		recording, err := LoadInputRecording(path)
+		if err != nil {
+			internal.GetInternalLogger().Error("Failed to load input recording", "path", path, "error", err)
+		} else {
+			internal.GetInternalLogger().Info("Replaying input recording", "path", path, "events", len(recording.Events))
+			ReplayInput(recording)
+		}
+	}
+
+	if os.Getenv(InputRecordPathEnvVar) != "" {
+		StartInputRecording()
+		internal.OnCancelApp(flushInputRecording)
+	}
+}
+
+// flushInputRecording saves the recording so far without stopping it, so a session that ends
+// in a power-off or a kill still leaves the input up to the last screen behind
+func flushInputRecording() {
+	path := os.Getenv(InputRecordPathEnvVar)
+	if path == "" {
+		return
+	}
+
+	recording := internal.GetInputProcessor().RecordingSnapshot()
+	if recording == nil {
+		return
+	}
+
+	if err := recording.SaveToJSON(path); err != nil {
+		internal.GetInternalLogger().Error("Failed to save input recording", "path", path, "error", err)
+	}
+}
+
+func saveInputRecording() {
+	path := os.Getenv(InputRecordPathEnvVar)
+	if path == "" {
+		return
+	}
+
+	recording := StopInputRecording()
+	if recording == nil {
+		return
+	}
+
+	if err := recording.SaveToJSON(path); err != nil {
+		internal.GetInternalLogger().Error("Failed to save input recording", "path", path, "error", err)
+	}
+}
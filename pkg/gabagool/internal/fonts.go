@@ -24,9 +24,10 @@ var DefaultFontSizes = FontSizes{
 	Micro:  18,
 }
 
-var Fonts fontsManager
+var Fonts FontsManager
 
-type fontsManager struct {
+// FontsManager holds the themed fonts at each size, scaled for the current resolution.
+type FontsManager struct {
 	ExtraLargeFont *ttf.Font
 	LargeFont      *ttf.Font
 	MediumFont     *ttf.Font
@@ -72,6 +73,10 @@ func initFonts(sizes FontSizes) {
 	screenWidth := GetWindow().GetWidth()
 	fontPath := GetTheme().FontPath
 	fallback := os.Getenv("FALLBACK_FONT")
+	if fallback == "" && headless {
+		// There is no device font directory when running headless
+		fallback = fontPath
+	}
 	symbolPath := "/mnt/SDCARD/.system/res/font1.ttf"
 
 	// Calculate all sizes
@@ -79,7 +84,7 @@ func initFonts(sizes FontSizes) {
 		return CalculateFontSizeForResolution(base, screenWidth)
 	}
 
-	Fonts = fontsManager{
+	Fonts = FontsManager{
 		ExtraLargeFont:   loadFont(fontPath, fallback, calcSize(sizes.XLarge)),
 		LargeFont:        loadFont(fontPath, fallback, calcSize(sizes.Large)),
 		MediumFont:       loadFont(fontPath, fallback, calcSize(sizes.Medium)),
@@ -126,6 +131,9 @@ func loadFont(path string, fallback string, size int) *ttf.Font {
 }
 
 func closeFonts() {
+	// Closed fonts may be reallocated at the same address
+	clear(truncateCache)
+
 	Fonts.LargeFont.Close()
 	Fonts.MediumFont.Close()
 	Fonts.SmallFont.Close()
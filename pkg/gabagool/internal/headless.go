@@ -0,0 +1,104 @@
+package internal
+
+import (
+	"fmt"
+	"image"
+	"os"
+	"unsafe"
+
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+const (
+	DefaultHeadlessWidth  int32 = 1024
+	DefaultHeadlessHeight int32 = 768
+)
+
+var headless bool
+
+// IsHeadless reports whether the UI is rendering to an offscreen software target.
+func IsHeadless() bool {
+	return headless
+}
+
+// InitHeadless initializes SDL without a display. Frames are drawn by the software
+// renderer into an offscreen framebuffer and can be read back with CaptureFrame.
+func InitHeadless(title string, width, height int32) {
+	headless = true
+	ResetAppContext()
+
+	if width <= 0 {
+		width = DefaultHeadlessWidth
+	}
+	if height <= 0 {
+		height = DefaultHeadlessHeight
+	}
+
+	// The dummy driver provides a framebuffer without needing X11, Wayland or KMS
+	os.Setenv("SDL_VIDEODRIVER", "dummy")
+
+	if err := sdl.Init(sdl.INIT_VIDEO | sdl.INIT_EVENTS); err != nil {
+		GetInternalLogger().Error("Failed to initialize headless SDL", "error", err)
+		os.Exit(1)
+	}
+
+	if err := ttf.Init(); err != nil {
+		os.Exit(1)
+	}
+
+	InitInputProcessor()
+
+	window = initHeadlessWindow(title, width, height)
+
+	initFonts(DefaultFontSizes)
+}
+
+func initHeadlessWindow(title string, width, height int32) *Window {
+	GetInternalLogger().Debug("Initializing headless SDL Window", "width", width, "height", height)
+
+	sdlWindow, err := sdl.CreateWindow(title, 0, 0, width, height, sdl.WINDOW_HIDDEN)
+	if err != nil {
+		panic(err)
+	}
+
+	renderer, err := sdl.CreateRenderer(sdlWindow, -1, sdl.RENDERER_SOFTWARE|sdl.RENDERER_TARGETTEXTURE)
+	if err != nil {
+		GetInternalLogger().Error("Failed to create software renderer!", "error", err)
+		os.Exit(1)
+	}
+
+	renderer.SetLogicalSize(width, height)
+
+	win := &Window{
+		Window:   sdlWindow,
+		Renderer: renderer,
+		Title:    title,
+	}
+
+	win.loadBackground()
+
+	return win
+}
+
+// CaptureFrame reads back the most recently rendered frame as an RGBA image.
+func CaptureFrame() (*image.RGBA, error) {
+	if window == nil {
+		return nil, fmt.Errorf("window not initialized")
+	}
+
+	width, height, err := window.Renderer.GetOutputSize()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get output size: %w", err)
+	}
+
+	frame := image.NewRGBA(image.Rect(0, 0, int(width), int(height)))
+
+	// ABGR8888 is laid out as R, G, B, A bytes on little-endian machines, matching image.RGBA
+	err = window.Renderer.ReadPixels(nil, sdl.PIXELFORMAT_ABGR8888, unsafe.Pointer(&frame.Pix[0]), frame.Stride)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read pixels: %w", err)
+	}
+
+	return frame, nil
+}
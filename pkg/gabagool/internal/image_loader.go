@@ -0,0 +1,298 @@
+package internal
+
+import (
+	"container/list"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/veandco/go-sdl2/img"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// DefaultImageCacheBytes is the texture memory the image loader keeps before evicting the least recently used images
+const DefaultImageCacheBytes int64 = 48 << 20
+
+const (
+	// imageWorkers is how many images are decoded at once
+	imageWorkers = 2
+	// maxQueuedImages caps the decode queue; the oldest requests are dropped beyond it
+	maxQueuedImages = 64
+	// imageRequestTimeout drops queued images that have not been asked for again in this long,
+	// such as rows scrolled past before their image was decoded
+	imageRequestTimeout = time.Second
+)
+
+type ImageStatus int
+
+const (
+	ImageLoading ImageStatus = iota
+	ImageReady
+	ImageFailed
+)
+
+type imageKey struct {
+	path          string
+	width, height int32
+}
+
+type imageEntry struct {
+	key     imageKey
+	texture *sdl.Texture
+	bytes   int64
+}
+
+type decodedImage struct {
+	key     imageKey
+	surface *sdl.Surface
+}
+
+type queuedImage struct {
+	key       imageKey
+	requested time.Time
+}
+
+// ImageLoader decodes and downscales images on a few background workers and uploads them as textures
+// on the render thread, keeping the most recently used ones up to a memory limit.
+// The most recently requested image is decoded first.
+// Textures it returns are owned by the loader: do not destroy them, and fetch them again every frame
+// since they may be evicted between frames.
+type ImageLoader struct {
+	mu       sync.Mutex
+	maxBytes int64
+	used     int64
+	entries  map[imageKey]*list.Element
+	lru      *list.List
+	pending  map[imageKey]bool
+	failed   map[imageKey]bool
+	decoded  []decodedImage
+
+	queue   []queuedImage
+	ready   *sync.Cond
+	started bool
+	// generation changes with every Destroy so workers of a destroyed session stop and free what they decoded
+	generation int
+}
+
+var (
+	imageLoader     *ImageLoader
+	imageLoaderOnce sync.Once
+)
+
+// GetImageLoader returns the loader shared by all components
+func GetImageLoader() *ImageLoader {
+	imageLoaderOnce.Do(func() {
+		imageLoader = NewImageLoader(DefaultImageCacheBytes)
+	})
+	return imageLoader
+}
+
+func NewImageLoader(maxBytes int64) *ImageLoader {
+	l := &ImageLoader{
+		maxBytes: maxBytes,
+		entries:  make(map[imageKey]*list.Element),
+		lru:      list.New(),
+		pending:  make(map[imageKey]bool),
+		failed:   make(map[imageKey]bool),
+	}
+	l.ready = sync.NewCond(&l.mu)
+	return l
+}
+
+// SetLimit changes the memory limit. Textures over the limit are evicted on the next Upload.
+func (l *ImageLoader) SetLimit(maxBytes int64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.maxBytes = maxBytes
+}
+
+// Texture returns the image at path scaled down to fit within maxWidth by maxHeight, keeping its aspect ratio.
+// A zero dimension is not limited. Until the image has been decoded and uploaded it returns nil with ImageLoading.
+// Must be called from the render thread.
+func (l *ImageLoader) Texture(path string, maxWidth, maxHeight int32) (*sdl.Texture, ImageStatus) {
+	if path == "" {
+		return nil, ImageFailed
+	}
+
+	key := imageKey{path: path, width: maxWidth, height: maxHeight}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if element, ok := l.entries[key]; ok {
+		l.lru.MoveToFront(element)
+		return element.Value.(*imageEntry).texture, ImageReady
+	}
+	if l.failed[key] {
+		return nil, ImageFailed
+	}
+
+	l.request(key, time.Now())
+	if !l.started {
+		l.started = true
+		for range imageWorkers {
+			go l.work(l.generation)
+		}
+	}
+	return nil, ImageLoading
+}
+
+// request queues key for decoding, or moves it to the front of the queue when it is already waiting.
+// Must be called with the lock held.
+func (l *ImageLoader) request(key imageKey, now time.Time) {
+	if position := slices.IndexFunc(l.queue, func(queued queuedImage) bool { return queued.key == key }); position >= 0 {
+		l.queue = slices.Delete(l.queue, position, position+1)
+	} else if l.pending[key] {
+		// Already being decoded
+		return
+	}
+
+	l.pending[key] = true
+	l.queue = append(l.queue, queuedImage{key: key, requested: now})
+	if len(l.queue) > maxQueuedImages {
+		delete(l.pending, l.queue[0].key)
+		l.queue = slices.Delete(l.queue, 0, 1)
+	}
+	l.ready.Signal()
+}
+
+// next takes the most recently requested image off the queue, dropping any that have not been asked for lately.
+// Must be called with the lock held.
+func (l *ImageLoader) next(now time.Time) (imageKey, bool) {
+	for len(l.queue) > 0 {
+		queued := l.queue[len(l.queue)-1]
+		l.queue = l.queue[:len(l.queue)-1]
+		if now.Sub(queued.requested) <= imageRequestTimeout {
+			return queued.key, true
+		}
+		delete(l.pending, queued.key)
+	}
+	return imageKey{}, false
+}
+
+// work decodes queued images until the loader is destroyed
+func (l *ImageLoader) work(generation int) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for {
+		key, ok := l.next(time.Now())
+		for !ok && l.generation == generation {
+			l.ready.Wait()
+			key, ok = l.next(time.Now())
+		}
+		if l.generation != generation {
+			return
+		}
+
+		l.mu.Unlock()
+		surface, err := loadScaledSurface(key.path, key.width, key.height)
+		l.mu.Lock()
+
+		switch {
+		case l.generation != generation:
+			if surface != nil {
+				surface.Free()
+			}
+			return
+		case err != nil:
+			GetInternalLogger().Debug("Failed to load image", "path", key.path, "error", err)
+			delete(l.pending, key)
+			l.failed[key] = true
+		default:
+			l.decoded = append(l.decoded, decodedImage{key: key, surface: surface})
+		}
+	}
+}
+
+// Upload turns decoded images into textures and evicts the least recently used ones over the limit.
+// The screen runtime calls it once per frame before rendering.
+func (l *ImageLoader) Upload(renderer *sdl.Renderer) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, decoded := range l.decoded {
+		delete(l.pending, decoded.key)
+
+		texture, err := renderer.CreateTextureFromSurface(decoded.surface)
+		bytes := int64(decoded.surface.W) * int64(decoded.surface.H) * 4
+		decoded.surface.Free()
+		if err != nil {
+			l.failed[decoded.key] = true
+			continue
+		}
+
+		l.entries[decoded.key] = l.lru.PushFront(&imageEntry{key: decoded.key, texture: texture, bytes: bytes})
+		l.used += bytes
+	}
+	l.decoded = l.decoded[:0]
+
+	// Always keep the most recent image, even if it alone is over the limit
+	for l.used > l.maxBytes && l.lru.Len() > 1 {
+		l.evict(l.lru.Back())
+	}
+}
+
+func (l *ImageLoader) evict(element *list.Element) {
+	entry := l.lru.Remove(element).(*imageEntry)
+	delete(l.entries, entry.key)
+	l.used -= entry.bytes
+	entry.texture.Destroy()
+}
+
+// Destroy frees every cached texture and pending surface and stops the workers.
+// Images still being decoded are freed by their worker once it finishes.
+// The loader can be used again afterwards, as it is after Close and a new Init; new workers start on the next request.
+func (l *ImageLoader) Destroy() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.generation++
+	l.started = false
+	l.ready.Broadcast()
+	l.queue = nil
+
+	for l.lru.Len() > 0 {
+		l.evict(l.lru.Back())
+	}
+	for _, decoded := range l.decoded {
+		decoded.surface.Free()
+	}
+	l.decoded = nil
+	l.pending = make(map[imageKey]bool)
+	l.failed = make(map[imageKey]bool)
+}
+
+// loadScaledSurface decodes path and, when it is larger than maxWidth by maxHeight, scales it down to fit
+func loadScaledSurface(path string, maxWidth, maxHeight int32) (*sdl.Surface, error) {
+	surface, err := img.Load(path)
+	if err != nil {
+		return nil, err
+	}
+
+	width, height := surface.W, surface.H
+	if maxWidth > 0 && width > maxWidth {
+		height = int32(float32(height) * float32(maxWidth) / float32(width))
+		width = maxWidth
+	}
+	if maxHeight > 0 && height > maxHeight {
+		width = int32(float32(width) * float32(maxHeight) / float32(height))
+		height = maxHeight
+	}
+	if width == surface.W && height == surface.H {
+		return surface, nil
+	}
+	defer surface.Free()
+
+	scaled, err := sdl.CreateRGBSurfaceWithFormat(0, max(width, 1), max(height, 1), 32, uint32(sdl.PIXELFORMAT_RGBA32))
+	if err != nil {
+		return nil, err
+	}
+
+	surface.SetBlendMode(sdl.BLENDMODE_NONE)
+	if err := surface.BlitScaled(nil, scaled, nil); err != nil {
+		scaled.Free()
+		return nil, err
+	}
+	return scaled, nil
+}
@@ -0,0 +1,107 @@
+package internal
+
+import (
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestImageLoaderQueue(t *testing.T) {
+	start := time.Now()
+	key := func(path string) imageKey { return imageKey{path: path, width: 64, height: 64} }
+
+	tests := []struct {
+		name     string
+		requests []string
+		gap      time.Duration
+		want     []string
+	}{
+		{name: "most recent first", requests: []string{"a", "b", "c"}, want: []string{"c", "b", "a"}},
+		{name: "asking again moves to the front", requests: []string{"a", "b", "c", "a"}, want: []string{"a", "c", "b"}},
+		{name: "stale requests are dropped", requests: []string{"a", "b"}, gap: imageRequestTimeout, want: []string{"b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			l := NewImageLoader(DefaultImageCacheBytes)
+			now := start
+			for _, path := range tt.requests {
+				l.request(key(path), now)
+				now = now.Add(tt.gap)
+			}
+
+			var got []string
+			for {
+				next, ok := l.next(now.Add(-tt.gap + time.Millisecond))
+				if !ok {
+					break
+				}
+				got = append(got, next.path)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("decoded %q, want %q", got, tt.want)
+			}
+			for _, path := range tt.requests {
+				if !slices.Contains(tt.want, path) && l.pending[key(path)] {
+					t.Errorf("dropped request %q is still pending", path)
+				}
+			}
+		})
+	}
+}
+
+func TestImageLoaderQueueLimit(t *testing.T) {
+	l := NewImageLoader(DefaultImageCacheBytes)
+	now := time.Now()
+	for i := range maxQueuedImages + 1 {
+		l.request(imageKey{path: fmt.Sprint(i)}, now)
+	}
+
+	if len(l.queue) != maxQueuedImages {
+		t.Fatalf("queue holds %d images, want %d", len(l.queue), maxQueuedImages)
+	}
+	if l.pending[imageKey{path: "0"}] {
+		t.Error("the oldest request was not dropped")
+	}
+}
+
+func TestImageLoaderReuseAfterDestroy(t *testing.T) {
+	l := NewImageLoader(DefaultImageCacheBytes)
+	now := time.Now()
+
+	// A worker of the first session waits for work until Destroy stops it
+	l.mu.Lock()
+	generation := l.generation
+	l.started = true
+	l.mu.Unlock()
+	stopped := make(chan struct{})
+	go func() {
+		l.work(generation)
+		close(stopped)
+	}()
+
+	l.mu.Lock()
+	l.failed[imageKey{path: "broken"}] = true
+	l.mu.Unlock()
+
+	l.Destroy()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("worker of the destroyed session did not stop")
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.started || len(l.queue) != 0 || len(l.pending) != 0 || len(l.failed) != 0 || l.lru.Len() != 0 {
+		t.Fatalf("loader not reset: started %v, %d queued, %d pending, %d failed, %d cached",
+			l.started, len(l.queue), len(l.pending), len(l.failed), l.lru.Len())
+	}
+
+	l.request(imageKey{path: "a"}, now)
+	if key, ok := l.next(now); !ok || key.path != "a" {
+		t.Errorf("next() after Destroy = %v, %v, want the new request", key, ok)
+	}
+}
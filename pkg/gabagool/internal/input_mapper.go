@@ -21,10 +21,10 @@ const (
 )
 
 type Event struct {
-	Button  constants.VirtualButton
-	Pressed bool
-	Source  Source
-	RawCode int
+	Button  constants.VirtualButton `json:"button"`
+	Pressed bool                    `json:"pressed"`
+	Source  Source                  `json:"source"`
+	RawCode int                     `json:"raw_code"`
 }
 
 type JoystickAxisMapping struct {
@@ -2,6 +2,8 @@ package internal
 
 import (
 	"fmt"
+	"sync"
+	"time"
 
 	"github.com/veandco/go-sdl2/sdl"
 )
@@ -67,6 +69,15 @@ type Processor struct {
 	axisStates                    map[uint8]int8  // tracks which direction each axis is pressed: -1 (negative), 0 (none), 1 (positive)
 	hatStates                     map[uint8]uint8 // tracks the current hat position
 	eventQueue                    []*Event        // queue for events that need to be processed
+
+	script         []scriptStep // synthetic input consumed by PollEvent
+	scriptYield    bool
+	lastScriptStep time.Time
+	scriptMutex    sync.Mutex
+
+	recording      *InputRecording
+	recordingStart time.Time
+	recordingMutex sync.Mutex
 }
 
 func NewInputProcessor() *Processor {
@@ -86,7 +97,17 @@ func (ip *Processor) IsGameControllerJoystick(joystickIndex int) bool {
 	return ip.gameControllerJoystickIndices[joystickIndex]
 }
 
+// ProcessSDLEvent maps an SDL event to a virtual button event, or nil if it is not mapped.
+// Mapped events are appended to the active recording, if any.
 func (ip *Processor) ProcessSDLEvent(event sdl.Event) *Event {
+	inputEvent := ip.processSDLEvent(event)
+	if inputEvent != nil {
+		ip.recordEvent(inputEvent)
+	}
+	return inputEvent
+}
+
+func (ip *Processor) processSDLEvent(event sdl.Event) *Event {
 	// If there are queued events, return those first
 	if len(ip.eventQueue) > 0 {
 		evt := ip.eventQueue[0]
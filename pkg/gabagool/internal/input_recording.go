@@ -0,0 +1,116 @@
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+)
+
+// RecordedEvent is an input event with its offset from the start of the recording.
+type RecordedEvent struct {
+	Event
+	OffsetMs int64 `json:"offset_ms"`
+}
+
+// InputRecording is a captured input session that can be saved and replayed.
+type InputRecording struct {
+	Events []RecordedEvent `json:"events"`
+}
+
+// StartRecording begins capturing every mapped input event, replacing any active recording.
+func (ip *Processor) StartRecording() {
+	ip.recordingMutex.Lock()
+	defer ip.recordingMutex.Unlock()
+	ip.recording = &InputRecording{Events: []RecordedEvent{}}
+	ip.recordingStart = time.Now()
+}
+
+// StopRecording ends the active recording and returns it, or nil if none was active.
+func (ip *Processor) StopRecording() *InputRecording {
+	ip.recordingMutex.Lock()
+	defer ip.recordingMutex.Unlock()
+	recording := ip.recording
+	ip.recording = nil
+	return recording
+}
+
+// RecordingSnapshot returns a copy of the active recording so far, or nil if none is active.
+// Recording carries on, so the snapshot can be saved while the session is still running.
+func (ip *Processor) RecordingSnapshot() *InputRecording {
+	ip.recordingMutex.Lock()
+	defer ip.recordingMutex.Unlock()
+	if ip.recording == nil {
+		return nil
+	}
+	return &InputRecording{Events: slices.Clone(ip.recording.Events)}
+}
+
+// IsRecording reports whether input is currently being recorded.
+func (ip *Processor) IsRecording() bool {
+	ip.recordingMutex.Lock()
+	defer ip.recordingMutex.Unlock()
+	return ip.recording != nil
+}
+
+// recordEvent adds event to the active recording, if any
+func (ip *Processor) recordEvent(event *Event) {
+	ip.recordingMutex.Lock()
+	defer ip.recordingMutex.Unlock()
+	if ip.recording == nil {
+		return
+	}
+	ip.recording.Events = append(ip.recording.Events, RecordedEvent{
+		Event:    *event,
+		OffsetMs: time.Since(ip.recordingStart).Milliseconds(),
+	})
+}
+
+// Replay injects a recording, preserving the time between events.
+func (ip *Processor) Replay(recording *InputRecording) {
+	if recording == nil {
+		return
+	}
+
+	var previousOffset int64
+	for _, recorded := range recording.Events {
+		delay := time.Duration(recorded.OffsetMs-previousOffset) * time.Millisecond
+		ip.Inject(recorded.Event, max(delay, 0))
+		previousOffset = recorded.OffsetMs
+	}
+}
+
+func LoadInputRecordingFromJSON(filePath string) (*InputRecording, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read JSON file: %w", err)
+	}
+
+	var recording InputRecording
+	err = json.Unmarshal(data, &recording)
+	if err != nil {
+		return nil, fmt.Errorf("failed to unmarshal JSON: %w", err)
+	}
+
+	return &recording, nil
+}
+
+func (r *InputRecording) SaveToJSON(filePath string) error {
+	data, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal recording to JSON: %w", err)
+	}
+
+	// Write to a temporary file first so a power cut never leaves a half written recording behind
+	tmpPath := filePath + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, filePath); err != nil {
+		return fmt.Errorf("failed to replace JSON file: %w", err)
+	}
+
+	return nil
+}
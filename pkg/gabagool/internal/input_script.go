@@ -0,0 +1,129 @@
+package internal
+
+import (
+	"time"
+
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// scriptStep is a single entry in the scripted input stream.
+// Exactly one of event, action or quit is used.
+// delay holds the step back until that long after the previous step was delivered.
+type scriptStep struct {
+	event  *Event
+	action func()
+	quit   bool
+	delay  time.Duration
+}
+
+// Inject feeds a synthetic event into the input pipeline after delay.
+// The delay is measured from the previously injected event.
+func (ip *Processor) Inject(event Event, delay time.Duration) {
+	ip.scriptMutex.Lock()
+	defer ip.scriptMutex.Unlock()
+
+	ip.appendStep(scriptStep{event: &event, delay: delay})
+}
+
+// appendStep must be called with scriptMutex held.
+func (ip *Processor) appendStep(step scriptStep) {
+	if len(ip.script) == 0 {
+		ip.lastScriptStep = time.Now()
+	}
+	ip.script = append(ip.script, step)
+}
+
+// QueueScriptedEvents appends synthetic input events to the scripted stream.
+func (ip *Processor) QueueScriptedEvents(events ...Event) {
+	ip.scriptMutex.Lock()
+	defer ip.scriptMutex.Unlock()
+
+	for i := range events {
+		event := events[i]
+		ip.appendStep(scriptStep{event: &event})
+	}
+}
+
+// QueueScriptedAction appends a callback that runs once every earlier step has been rendered.
+func (ip *Processor) QueueScriptedAction(action func()) {
+	ip.scriptMutex.Lock()
+	defer ip.scriptMutex.Unlock()
+
+	ip.appendStep(scriptStep{action: action})
+}
+
+// scriptedQuit is the quit event delivered for QueueScriptedQuit.
+// It only ends the current screen, unlike a real quit which also cancels AppContext.
+var scriptedQuit = &sdl.QuitEvent{Type: sdl.QUIT}
+
+// IsScriptedQuit reports whether event was produced by QueueScriptedQuit.
+func IsScriptedQuit(event sdl.Event) bool {
+	quit, ok := event.(*sdl.QuitEvent)
+	return ok && quit == scriptedQuit
+}
+
+// QueueScriptedQuit appends an SDL quit event to the scripted stream.
+func (ip *Processor) QueueScriptedQuit() {
+	ip.scriptMutex.Lock()
+	defer ip.scriptMutex.Unlock()
+
+	ip.appendStep(scriptStep{quit: true})
+}
+
+// nextScriptedEvent returns the SDL event for the next scripted step.
+// It returns nil after every delivered step so each step gets its own frame.
+func (ip *Processor) nextScriptedEvent() sdl.Event {
+	ip.scriptMutex.Lock()
+
+	if ip.scriptYield {
+		ip.scriptYield = false
+		ip.scriptMutex.Unlock()
+		return nil
+	}
+
+	if len(ip.script) == 0 || time.Since(ip.lastScriptStep) < ip.script[0].delay {
+		ip.scriptMutex.Unlock()
+		return nil
+	}
+
+	step := ip.script[0]
+	ip.script = ip.script[1:]
+	ip.lastScriptStep = time.Now()
+	ip.scriptYield = step.action == nil
+	ip.scriptMutex.Unlock()
+
+	switch {
+	case step.quit:
+		return scriptedQuit
+	case step.action != nil:
+		step.action()
+		return nil
+	default:
+		// The marker is resolved back to the scripted event by ProcessSDLEvent
+		ip.eventQueue = append(ip.eventQueue, step.event)
+		return &sdl.UserEvent{Type: sdl.USEREVENT}
+	}
+}
+
+// PollEvent returns the next pending SDL event.
+// Once the SDL queue is drained, scripted input is delivered one step per frame.
+func PollEvent() sdl.Event {
+	if event := sdl.PollEvent(); event != nil {
+		return event
+	}
+
+	if globalInputProcessor == nil {
+		return nil
+	}
+
+	return globalInputProcessor.nextScriptedEvent()
+}
+
+// ClearScript drops any scripted steps that have not been delivered yet.
+func (ip *Processor) ClearScript() {
+	ip.scriptMutex.Lock()
+	defer ip.scriptMutex.Unlock()
+
+	ip.script = nil
+	ip.scriptYield = false
+}
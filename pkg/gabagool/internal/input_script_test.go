@@ -0,0 +1,125 @@
+package internal
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// drainScript polls the scripted stream for frames frames and records what each frame delivered
+func drainScript(ip *Processor, frames int, delivered *[]string) {
+	for range frames {
+		switch event := ip.nextScriptedEvent().(type) {
+		case nil:
+			*delivered = append(*delivered, "-")
+		case *sdl.QuitEvent:
+			if IsScriptedQuit(event) {
+				*delivered = append(*delivered, "quit")
+			} else {
+				*delivered = append(*delivered, "real quit")
+			}
+		case *sdl.UserEvent:
+			queued := ip.eventQueue[len(ip.eventQueue)-1]
+			state := "up"
+			if queued.Pressed {
+				state = "down"
+			}
+			*delivered = append(*delivered, queued.Button.GetName()+" "+state)
+		default:
+			*delivered = append(*delivered, "unexpected")
+		}
+	}
+}
+
+func TestScriptedInput(t *testing.T) {
+	press := func(button constants.VirtualButton) Event {
+		return Event{Button: button, Pressed: true, Source: SourceKeyboard}
+	}
+	release := func(button constants.VirtualButton) Event {
+		return Event{Button: button, Pressed: false, Source: SourceKeyboard}
+	}
+
+	tests := []struct {
+		name   string
+		setup  func(ip *Processor, log *[]string)
+		frames int
+		want   []string
+	}{
+		{
+			name:   "empty script delivers nothing",
+			setup:  func(ip *Processor, log *[]string) {},
+			frames: 2,
+			want:   []string{"-", "-"},
+		},
+		{
+			name: "events are delivered one per frame",
+			setup: func(ip *Processor, log *[]string) {
+				ip.QueueScriptedEvents(press(constants.VirtualButtonA), release(constants.VirtualButtonA))
+			},
+			frames: 4,
+			want:   []string{"A down", "-", "A up", "-"},
+		},
+		{
+			name: "quit is the scripted quit event",
+			setup: func(ip *Processor, log *[]string) {
+				ip.QueueScriptedQuit()
+			},
+			frames: 2,
+			want:   []string{"quit", "-"},
+		},
+		{
+			name: "actions run without using up a frame",
+			setup: func(ip *Processor, log *[]string) {
+				ip.QueueScriptedAction(func() { *log = append(*log, "action") })
+				ip.QueueScriptedEvents(press(constants.VirtualButtonB))
+			},
+			frames: 2,
+			want:   []string{"action", "-", "B down"},
+		},
+		{
+			name: "delayed events wait",
+			setup: func(ip *Processor, log *[]string) {
+				ip.Inject(press(constants.VirtualButtonStart), time.Hour)
+			},
+			frames: 2,
+			want:   []string{"-", "-"},
+		},
+		{
+			name: "cleared steps are dropped",
+			setup: func(ip *Processor, log *[]string) {
+				ip.QueueScriptedEvents(press(constants.VirtualButtonA))
+				ip.ClearScript()
+			},
+			frames: 1,
+			want:   []string{"-"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ip := &Processor{}
+			var got []string
+			tt.setup(ip, &got)
+			drainScript(ip, tt.frames, &got)
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("delivered %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestIsScriptedQuit(t *testing.T) {
+	if IsScriptedQuit(&sdl.QuitEvent{Type: sdl.QUIT}) {
+		t.Error("a real quit event was reported as scripted")
+	}
+	if !IsScriptedQuit(scriptedQuit) {
+		t.Error("the scripted quit event was not recognised")
+	}
+	if IsScriptedQuit(&sdl.UserEvent{Type: sdl.USEREVENT}) {
+		t.Error("a non-quit event was reported as scripted quit")
+	}
+}
@@ -0,0 +1,61 @@
+package internal
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// ShutdownGracePeriod is how long running work gets to clean up before the shutdown command runs
+const ShutdownGracePeriod = 500 * time.Millisecond
+
+var (
+	appMutex    sync.Mutex
+	appCtx      context.Context
+	cancelApp   context.CancelFunc
+	cancelHooks []func()
+)
+
+func init() {
+	ResetAppContext()
+}
+
+// ResetAppContext replaces AppContext with a fresh one and drops the hooks added with OnCancelApp.
+// Init and InitHeadless call it so a new session is not born cancelled by the previous one.
+func ResetAppContext() {
+	appMutex.Lock()
+	defer appMutex.Unlock()
+
+	if cancelApp != nil {
+		cancelApp()
+	}
+	appCtx, cancelApp = context.WithCancel(context.Background())
+	cancelHooks = nil
+}
+
+// AppContext is cancelled once the app has been asked to quit or the device is shutting down
+func AppContext() context.Context {
+	appMutex.Lock()
+	defer appMutex.Unlock()
+	return appCtx
+}
+
+// CancelApp cancels AppContext and runs the hooks added with OnCancelApp. It is safe to call more than once.
+func CancelApp() {
+	appMutex.Lock()
+	cancelApp()
+	hooks := cancelHooks
+	appMutex.Unlock()
+
+	for _, hook := range hooks {
+		hook()
+	}
+}
+
+// OnCancelApp adds a hook that runs every time CancelApp is called, before the device powers off.
+// Hooks last until the next ResetAppContext.
+func OnCancelApp(hook func()) {
+	appMutex.Lock()
+	defer appMutex.Unlock()
+	cancelHooks = append(cancelHooks, hook)
+}
@@ -0,0 +1,29 @@
+package internal
+
+import "testing"
+
+func TestResetAppContext(t *testing.T) {
+	t.Cleanup(ResetAppContext)
+
+	calls := 0
+	OnCancelApp(func() { calls++ })
+	CancelApp()
+	CancelApp()
+
+	if AppContext().Err() == nil {
+		t.Fatal("AppContext was not cancelled")
+	}
+	if calls != 2 {
+		t.Errorf("hook ran %d times, want 2", calls)
+	}
+
+	ResetAppContext()
+	if err := AppContext().Err(); err != nil {
+		t.Fatalf("AppContext still cancelled after reset: %v", err)
+	}
+
+	CancelApp()
+	if calls != 2 {
+		t.Errorf("hook from the previous session ran after reset")
+	}
+}
@@ -58,6 +58,8 @@ func PowerButtonHandler(wg *sync.WaitGroup, config PowerButtonConfig) {
 				duration := time.Since(pressTime)
 				if duration >= config.ShortPressMax {
 					log.Println("Button held down for 2 seconds, shutting down...")
+					CancelApp()
+					time.Sleep(ShutdownGracePeriod)
 					runScript(config.ShutdownCommand)
 					cooldownUntil = time.Now().Add(config.CoolDownTime)
 				}
@@ -12,6 +12,8 @@ import (
 var window *Window
 
 func Init(title string, showBackground bool, pbc PowerButtonConfig) {
+	ResetAppContext()
+
 	if err := sdl.Init(sdl.INIT_VIDEO | sdl.INIT_AUDIO |
 		img.INIT_PNG | img.INIT_JPG | img.INIT_TIF | img.INIT_WEBP |
 		sdl.INIT_GAMECONTROLLER | sdl.INIT_JOYSTICK); err != nil {
@@ -0,0 +1,85 @@
+package internal
+
+import (
+	"sort"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// truncateCacheLimit caps how many truncated strings are remembered before the cache starts over
+const truncateCacheLimit = 1024
+
+type truncateKey struct {
+	font     *ttf.Font
+	text     string
+	maxWidth int32
+}
+
+// truncateCache remembers truncated text per font and width so rows drawn every frame are only measured once.
+// It is only used from the render loop.
+var truncateCache = make(map[truncateKey]string)
+
+// TruncateToWidth cuts text short with an ellipsis so it fits within maxWidth when drawn with font.
+// It returns "" when not even the ellipsis fits.
+func TruncateToWidth(font *ttf.Font, text string, maxWidth int32) string {
+	key := truncateKey{font: font, text: text, maxWidth: maxWidth}
+	if truncated, ok := truncateCache[key]; ok {
+		return truncated
+	}
+
+	truncated := truncateToWidth(font, text, maxWidth)
+	if len(truncateCache) >= truncateCacheLimit {
+		clear(truncateCache)
+	}
+	truncateCache[key] = truncated
+	return truncated
+}
+
+func truncateToWidth(font *ttf.Font, text string, maxWidth int32) string {
+	if width, _, err := font.SizeUTF8(text); err != nil || int32(width) <= maxWidth {
+		return text
+	}
+
+	// Find the longest prefix that still fits with the ellipsis
+	runes := []rune(text)
+	fits := func(length int) bool {
+		width, _, _ := font.SizeUTF8(string(runes[:length]) + "...")
+		return int32(width) <= maxWidth
+	}
+	length := sort.Search(len(runes), func(length int) bool { return !fits(length) }) - 1
+	if length < 0 {
+		return ""
+	}
+	return string(runes[:length]) + "..."
+}
+
+// RenderTextLine draws a single line of text vertically centered in the band of height starting at y.
+// x is where the text starts, its center or where it ends, depending on align. It returns the width drawn.
+func RenderTextLine(renderer *sdl.Renderer, font *ttf.Font, text string, color sdl.Color, x, y, height int32, align constants.TextAlign) int32 {
+	if text == "" {
+		return 0
+	}
+
+	surface, _ := font.RenderUTF8Blended(text, color)
+	if surface == nil {
+		return 0
+	}
+	defer surface.Free()
+
+	texture, _ := renderer.CreateTextureFromSurface(surface)
+	if texture == nil {
+		return 0
+	}
+	defer texture.Destroy()
+
+	switch align {
+	case constants.TextAlignCenter:
+		x -= surface.W / 2
+	case constants.TextAlignRight:
+		x -= surface.W
+	}
+	renderer.Copy(texture, nil, &sdl.Rect{X: x, Y: y + (height-surface.H)/2, W: surface.W, H: surface.H})
+	return surface.W
+}
@@ -118,7 +118,7 @@ func (window *Window) loadBackground() {
 }
 
 func (window *Window) closeWindow() {
-	if !constants.IsDevMode() {
+	if !constants.IsDevMode() && !headless {
 		window.PowerButtonWG.Done()
 	}
 
@@ -44,19 +44,9 @@ type virtualKeyboard struct {
 	CursorVisible    bool
 	LastCursorBlink  time.Time
 	CursorBlinkRate  time.Duration
-	helpOverlay      *helpOverlay
-	ShowingHelp      bool
 	EnterPressed     bool
 	InputDelay       time.Duration
-	lastInputTime    time.Time
-
-	heldDirections struct {
-		up, down, left, right bool
-	}
-	lastRepeatTime time.Time
-	repeatDelay    time.Duration
-	repeatInterval time.Duration
-	hasRepeated    bool
+	done             bool
 }
 
 var defaultKeyboardHelpLines = []string{
@@ -102,15 +92,9 @@ func createKeyboard(windowWidth, windowHeight int32) *virtualKeyboard {
 		CursorVisible:    true,
 		LastCursorBlink:  time.Now(),
 		CursorBlinkRate:  500 * time.Millisecond,
-		ShowingHelp:      false,
 		InputDelay:       100 * time.Millisecond,
-		lastInputTime:    time.Now(),
-		lastRepeatTime:   time.Now(),
-		repeatDelay:      150 * time.Millisecond,
-		repeatInterval:   50 * time.Millisecond,
 	}
 
-	kb.helpOverlay = newHelpOverlay("Keyboard Help", defaultKeyboardHelpLines)
 	setupKeyboardRects(kb, windowWidth, windowHeight)
 
 	return kb
@@ -265,6 +249,17 @@ func setupKeyboardRects(kb *virtualKeyboard, windowWidth, windowHeight int32) {
 }
 
 // KeyboardResult represents the result of the Keyboard component.
+// moveBy shifts the whole layout down by dy, for a keyboard laid out in the lower part of the screen
+func (kb *virtualKeyboard) moveBy(dy int32) {
+	for i := range kb.Keys {
+		kb.Keys[i].Rect.Y += dy
+	}
+	for _, rect := range []*sdl.Rect{&kb.BackspaceRect, &kb.EnterRect, &kb.SpaceRect, &kb.ShiftRect,
+		&kb.SymbolRect, &kb.TextInputRect, &kb.KeyboardRect} {
+		rect.Y += dy
+	}
+}
+
 type KeyboardResult struct {
 	Text string
 }
@@ -273,8 +268,6 @@ type KeyboardResult struct {
 // Returns ErrCancelled if the user exits without pressing Enter.
 func Keyboard(initialText string) (*KeyboardResult, error) {
 	window := internal.GetWindow()
-	renderer := window.Renderer
-	font := internal.Fonts.MediumFont
 
 	kb := createKeyboard(window.GetWidth(), window.GetHeight())
 	if initialText != "" {
@@ -282,17 +275,7 @@ func Keyboard(initialText string) (*KeyboardResult, error) {
 		kb.CursorPosition = len(initialText)
 	}
 
-	for {
-		if kb.handleEvents() {
-			break
-		}
-
-		kb.handleDirectionalRepeats()
-
-		kb.updateCursorBlink()
-		kb.render(renderer, font)
-		sdl.Delay(16)
-	}
+	RunScreen(kb, kb.screenOptions())
 
 	if kb.EnterPressed {
 		return &KeyboardResult{Text: kb.TextBuffer}, nil
@@ -300,79 +283,37 @@ func Keyboard(initialText string) (*KeyboardResult, error) {
 	return nil, ErrCancelled
 }
 
-func (kb *virtualKeyboard) handleEvents() bool {
-	processor := internal.GetInputProcessor()
-
-	for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-		switch event.(type) {
-		case *sdl.QuitEvent:
-			return true
-
-		case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
-			inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-			if inputEvent == nil {
-				continue
-			}
-
-			if inputEvent.Pressed {
-				if kb.handleInputEvent(inputEvent) {
-					return true
-				}
-			} else {
-				kb.handleInputEventRelease(inputEvent)
-			}
-		}
-	}
-	return false
+func (kb *virtualKeyboard) screenOptions() ScreenOptions {
+	options := DefaultScreenOptions()
+	options.InputDelay = kb.InputDelay
+	options.EnableHelp = true
+	options.HelpTitle = "Keyboard Help"
+	options.HelpText = defaultKeyboardHelpLines
+	return options
 }
 
-func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
-	// Rate limit navigation to prevent too-fast input
-	if kb.isDirectionalButton(inputEvent.Button) {
-		if time.Since(kb.lastInputTime) < kb.InputDelay {
-			return false
-		}
-		kb.lastInputTime = time.Now()
+func (kb *virtualKeyboard) HandleInput(inputEvent *InputEvent) {
+	if inputEvent.Pressed && kb.handleInputEvent(inputEvent) {
+		kb.done = true
 	}
+}
 
-	button := inputEvent.Button
+func (kb *virtualKeyboard) Update() {
+	kb.updateCursorBlink()
+}
 
-	// Help toggle - always available
-	if button == constants.VirtualButtonMenu {
-		kb.toggleHelp()
-		return false
-	}
+func (kb *virtualKeyboard) Render(renderer *sdl.Renderer) {
+	kb.render(renderer, internal.Fonts.MediumFont)
+}
 
-	// If help is showing, handle help-specific input
-	if kb.ShowingHelp {
-		return kb.handleHelpInputEvent(button)
-	}
+func (kb *virtualKeyboard) Done() bool {
+	return kb.done
+}
 
-	// Handle keyboard input
-	switch button {
-	case constants.VirtualButtonUp:
-		kb.navigate(button)
-		kb.heldDirections.up = true
-		kb.heldDirections.down = false
-		kb.lastRepeatTime = time.Now()
-		return false
-	case constants.VirtualButtonDown:
-		kb.navigate(button)
-		kb.heldDirections.down = true
-		kb.heldDirections.up = false
-		kb.lastRepeatTime = time.Now()
-		return false
-	case constants.VirtualButtonLeft:
-		kb.navigate(button)
-		kb.heldDirections.left = true
-		kb.heldDirections.right = false
-		kb.lastRepeatTime = time.Now()
-		return false
-	case constants.VirtualButtonRight:
-		kb.navigate(button)
-		kb.heldDirections.right = true
-		kb.heldDirections.left = false
-		kb.lastRepeatTime = time.Now()
+func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
+	switch inputEvent.Button {
+	case constants.VirtualButtonUp, constants.VirtualButtonDown, constants.VirtualButtonLeft, constants.VirtualButtonRight:
+		kb.navigate(inputEvent.Button)
 		return false
 	case constants.VirtualButtonA:
 		kb.processSelection()
@@ -402,73 +343,6 @@ func (kb *virtualKeyboard) handleInputEvent(inputEvent *internal.Event) bool {
 	return false
 }
 
-func (kb *virtualKeyboard) isDirectionalButton(button constants.VirtualButton) bool {
-	return button == constants.VirtualButtonUp || button == constants.VirtualButtonDown ||
-		button == constants.VirtualButtonLeft || button == constants.VirtualButtonRight
-}
-
-func (kb *virtualKeyboard) handleHelpInputEvent(button constants.VirtualButton) bool {
-	switch button {
-	case constants.VirtualButtonUp:
-		kb.scrollHelpOverlay(-1)
-		return false
-	case constants.VirtualButtonDown:
-		kb.scrollHelpOverlay(1)
-		return false
-	default:
-		kb.ShowingHelp = false
-		return false
-	}
-}
-
-func (kb *virtualKeyboard) handleInputEventRelease(inputEvent *internal.Event) {
-	switch inputEvent.Button {
-	case constants.VirtualButtonUp:
-		kb.heldDirections.up = false
-		kb.hasRepeated = false
-	case constants.VirtualButtonDown:
-		kb.heldDirections.down = false
-		kb.hasRepeated = false
-	case constants.VirtualButtonLeft:
-		kb.heldDirections.left = false
-		kb.hasRepeated = false
-	case constants.VirtualButtonRight:
-		kb.heldDirections.right = false
-		kb.hasRepeated = false
-	}
-}
-
-func (kb *virtualKeyboard) handleDirectionalRepeats() {
-	if !kb.heldDirections.up && !kb.heldDirections.down && !kb.heldDirections.left && !kb.heldDirections.right {
-		kb.lastRepeatTime = time.Now()
-		kb.hasRepeated = false
-		return
-	}
-
-	timeSince := time.Since(kb.lastRepeatTime)
-
-	// Use repeatDelay for first repeat, then repeatInterval for subsequent repeats
-	threshold := kb.repeatInterval
-	if !kb.hasRepeated {
-		threshold = kb.repeatDelay
-	}
-
-	if timeSince >= threshold {
-		kb.lastRepeatTime = time.Now()
-		kb.hasRepeated = true
-
-		if kb.heldDirections.up {
-			kb.navigate(constants.VirtualButtonUp)
-		} else if kb.heldDirections.down {
-			kb.navigate(constants.VirtualButtonDown)
-		} else if kb.heldDirections.left {
-			kb.navigate(constants.VirtualButtonLeft)
-		} else if kb.heldDirections.right {
-			kb.navigate(constants.VirtualButtonRight)
-		}
-	}
-}
-
 func (kb *virtualKeyboard) navigate(button constants.VirtualButton) {
 	layout := createKeyLayout()
 	currentRow, currentCol := kb.findCurrentPosition(layout)
@@ -683,45 +557,17 @@ func (kb *virtualKeyboard) resetPressedKeys() {
 	}
 }
 
-func (kb *virtualKeyboard) toggleHelp() {
-	if kb.helpOverlay == nil {
-		kb.helpOverlay = newHelpOverlay("Keyboard Help", defaultKeyboardHelpLines)
-	}
-	kb.helpOverlay.toggle()
-	kb.ShowingHelp = kb.helpOverlay.ShowingHelp
-}
-
-func (kb *virtualKeyboard) scrollHelpOverlay(direction int) {
-	if kb.helpOverlay != nil {
-		kb.helpOverlay.scroll(direction)
-	}
-}
-
 func (kb *virtualKeyboard) render(renderer *sdl.Renderer, font *ttf.Font) {
-	renderer.SetDrawColor(0, 0, 0, 255)
-	renderer.Clear()
-
 	window := internal.GetWindow()
 
 	if window.Background != nil {
 		window.RenderBackground()
-	} else {
-		renderer.SetDrawColor(0, 0, 0, 255)
-		renderer.Clear()
-	}
-
-	if !kb.ShowingHelp {
-		kb.renderTextInput(renderer, font)
-		kb.renderKeys(renderer, font)
-		kb.renderSpecialKeys(renderer)
-		kb.renderFooter(renderer)
-	}
-
-	if kb.ShowingHelp && kb.helpOverlay != nil {
-		kb.helpOverlay.render(renderer, internal.Fonts.SmallFont)
 	}
 
-	renderer.Present()
+	kb.renderTextInput(renderer, font)
+	kb.renderKeys(renderer, font)
+	kb.renderSpecialKeys(renderer)
+	kb.renderFooter(renderer)
 }
 
 func (kb *virtualKeyboard) renderTextInput(renderer *sdl.Renderer, font *ttf.Font) {
@@ -944,7 +790,7 @@ func (kb *virtualKeyboard) renderSpaceKey(renderer *sdl.Renderer) {
 }
 
 func (kb *virtualKeyboard) renderFooter(renderer *sdl.Renderer) {
-	renderFooter(
+	RenderFooter(
 		renderer,
 		internal.Fonts.SmallFont,
 		[]FooterHelpItem{
@@ -1,19 +1,28 @@
 package gabagool
 
 import (
+	"slices"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
 	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
-	"github.com/veandco/go-sdl2/img"
 	"github.com/veandco/go-sdl2/sdl"
 	"github.com/veandco/go-sdl2/ttf"
 )
 
 type ListOptions struct {
-	Title             string
-	Items             []MenuItem
+	Title      string
+	Items      []MenuItem
+	DataSource ListDataSource // Loads items on demand instead of Items; rows not loaded yet show PlaceholderText
+	Handle     *ListHandle    // Changes Items from other goroutines while the list is showing
+
+	// Sorts are the orderings SortButton cycles through, starting at SortIndex, shown after the title.
+	// While a sort is in use ListResult.Items is in its order and Selected indexes into it.
+	Sorts             []ListSort
+	SortIndex         int
+	SortButton        constants.VirtualButton
 	SelectedIndex     int
 	VisibleStartIndex int
 	MaxVisibleItems   int
@@ -23,6 +32,21 @@ type ListOptions struct {
 	EnableReordering  bool
 	EnableHelp        bool
 	EnableImages      bool
+	EnableFilter      bool
+	EnableIndexRail   bool
+
+	// StateID saves the list's state under this ID, in the file set with Options.ListStateFile.
+	// The list reopens focused where it was left, replacing SelectedIndex and VisibleStartIndex.
+	// EnableFavorites lets FavoriteButton star items and EnableRecent remembers the last RecentLimit
+	// items chosen; both are shown in groups at the top of the list. Neither applies with a DataSource.
+	StateID         string
+	EnableFavorites bool
+	EnableRecent    bool
+	FavoriteButton  constants.VirtualButton
+	FavoriteBadge   Badge
+	RecentLimit     int
+	FavoritesTitle  string
+	RecentTitle     string
 
 	StartInMultiSelectMode bool
 	DisableBackButton      bool
@@ -46,6 +70,38 @@ type ListOptions struct {
 	MultiSelectButton constants.VirtualButton
 	ReorderButton     constants.VirtualButton
 
+	// Holding RangeSelectButton in multi-select mode and moving selects every item passed over,
+	// or deselects them when starting from a selected item. The select all, none and invert
+	// buttons act while it is held. SelectedCountFormat is shown in the footer in multi-select mode.
+	RangeSelectButton     constants.VirtualButton
+	SelectAllButton       constants.VirtualButton
+	SelectNoneButton      constants.VirtualButton
+	InvertSelectionButton constants.VirtualButton
+	SelectedCountFormat   string
+
+	// FilterButton opens the filter when EnableFilter is set. B clears an active filter.
+	// Both modes narrow the list live: FilterModeKeyboard as each character is typed and
+	// FilterModeLetterJump as the letter changes.
+	// ListResult.Selected always holds indices into the unfiltered Items.
+	FilterButton       constants.VirtualButton
+	FilterMode         FilterMode
+	FilterMatch        FilterMatch
+	FilterEmptyMessage string
+
+	// PreviousSectionButton and NextSectionButton jump between sections when EnableIndexRail is set.
+	// Items are grouped by MenuItem.Section, or by the first letter of their text when it is empty.
+	PreviousSectionButton constants.VirtualButton
+	NextSectionButton     constants.VirtualButton
+
+	// ContextMenu is offered for items without a ContextMenu of their own. It opens with
+	// ContextMenuButton, or by holding A for ContextMenuLongPress; zero disables the long press.
+	// The chosen action exits the list with ListActionContextMenu and its ID in ListResult.ContextAction.
+	ContextMenu          []ContextMenuItem
+	ContextMenuButton    constants.VirtualButton
+	ContextMenuLongPress time.Duration
+
+	PlaceholderText string
+
 	EmptyMessage      string
 	EmptyMessageColor sdl.Color
 
@@ -55,21 +111,38 @@ type ListOptions struct {
 
 func DefaultListOptions(title string, items []MenuItem) ListOptions {
 	return ListOptions{
-		Title:             title,
-		Items:             items,
-		SelectedIndex:     0,
-		MaxVisibleItems:   9,
-		Margins:           internal.UniformPadding(20),
-		TitleAlign:        constants.TextAlignLeft,
-		TitleSpacing:      constants.DefaultTitleSpacing,
-		FooterTextColor:   sdl.Color{R: 180, G: 180, B: 180, A: 255},
-		ScrollSpeed:       4.0,
-		ScrollPauseTime:   1250,
-		InputDelay:        constants.DefaultInputDelay,
-		MultiSelectButton: constants.VirtualButtonSelect,
-		ReorderButton:     constants.VirtualButtonSelect,
-		EmptyMessage:      "No items available",
-		EmptyMessageColor: sdl.Color{R: 255, G: 255, B: 255, A: 255},
+		Title:                 title,
+		Items:                 items,
+		SelectedIndex:         0,
+		MaxVisibleItems:       9,
+		Margins:               internal.UniformPadding(20),
+		TitleAlign:            constants.TextAlignLeft,
+		TitleSpacing:          constants.DefaultTitleSpacing,
+		FooterTextColor:       sdl.Color{R: 180, G: 180, B: 180, A: 255},
+		ScrollSpeed:           4.0,
+		ScrollPauseTime:       1250,
+		InputDelay:            constants.DefaultInputDelay,
+		MultiSelectButton:     constants.VirtualButtonSelect,
+		ReorderButton:         constants.VirtualButtonSelect,
+		RangeSelectButton:     constants.VirtualButtonL1,
+		SelectAllButton:       constants.VirtualButtonX,
+		SelectNoneButton:      constants.VirtualButtonB,
+		InvertSelectionButton: constants.VirtualButtonY,
+		SelectedCountFormat:   "%d selected",
+		FavoriteButton:        constants.VirtualButtonL1,
+		FavoriteBadge:         Badge{Text: "★"},
+		RecentLimit:           5,
+		FavoritesTitle:        "Favorites",
+		RecentTitle:           "Recent",
+		FilterButton:          constants.VirtualButtonR1,
+		FilterEmptyMessage:    "No matching items",
+		PreviousSectionButton: constants.VirtualButtonL2,
+		NextSectionButton:     constants.VirtualButtonR2,
+		PlaceholderText:       "Loading...",
+		SortButton:            constants.VirtualButtonY,
+		ContextMenuLongPress:  600 * time.Millisecond,
+		EmptyMessage:          "No items available",
+		EmptyMessageColor:     sdl.Color{R: 255, G: 255, B: 255, A: 255},
 	}
 }
 
@@ -78,58 +151,69 @@ type listController struct {
 	SelectedItems map[int]bool
 	MultiSelect   bool
 	ReorderMode   bool
-	ShowingHelp   bool
 	StartY        int32
-	lastInputTime time.Time
 
-	helpOverlay     *helpOverlay
 	itemScrollData  map[int]*internal.TextScrollData
 	titleScrollData *internal.TextScrollData
 
-	heldDirections struct {
-		up, down, left, right bool
-	}
-	lastRepeatTime time.Time
-	repeatDelay    time.Duration
-	repeatInterval time.Duration
-	hasRepeated    bool
-}
+	filter      listFilter
+	typing      filterTyping
+	sections    []listSection
+	contextMenu contextMenuState
+	rangeSelect rangeSelection
 
-func newListController(options ListOptions) *listController {
-	selectedItems := make(map[int]bool)
-	if options.SelectedIndex < 0 || options.SelectedIndex >= len(options.Items) {
-		options.SelectedIndex = 0
-	}
+	state       *listState
+	favorites   map[string]bool
+	pinned      []pinnedItem
+	pinnedStale bool
 
-	for i := range options.Items {
-		if options.Items[i].Selected {
-			selectedItems[i] = true
-		}
-	}
+	hasSubtitles bool
+	data         *listDataState
 
-	var helpOverlay *helpOverlay
-	if options.EnableHelp {
-		helpOverlay = newHelpOverlay(options.HelpTitle, options.HelpText)
-	}
+	result    ListResult
+	done      bool
+	cancelled bool
+}
 
-	return &listController{
+func newListController(options ListOptions) *listController {
+	lc := &listController{
 		Options:         options,
-		SelectedItems:   selectedItems,
+		SelectedItems:   make(map[int]bool),
 		MultiSelect:     options.StartInMultiSelectMode,
 		StartY:          20,
-		lastInputTime:   time.Now(),
-		helpOverlay:     helpOverlay,
 		itemScrollData:  make(map[int]*internal.TextScrollData),
 		titleScrollData: &internal.TextScrollData{},
-		lastRepeatTime:  time.Now(),
-		repeatDelay:     150 * time.Millisecond,
-		repeatInterval:  50 * time.Millisecond,
 	}
+
+	if options.DataSource != nil {
+		lc.data = newListDataState(options.DataSource)
+		lc.Options.Items = nil
+		lc.resizeItems(options.DataSource.Count())
+	}
+
+	if lc.Options.SortIndex < 0 || lc.Options.SortIndex >= len(lc.Options.Sorts) {
+		lc.Options.SortIndex = 0
+	}
+	if lc.Options.SelectedIndex < 0 || lc.Options.SelectedIndex >= len(lc.Options.Items) {
+		lc.Options.SelectedIndex = 0
+	}
+	lc.Options.Items, lc.Options.SelectedIndex, _ = lc.sortItems(lc.Options.Items, lc.Options.SelectedIndex)
+	lc.restoreState()
+
+	for i := range lc.Options.Items {
+		if lc.Options.Items[i].Selected {
+			lc.SelectedItems[i] = true
+		}
+	}
+
+	lc.Options.SelectedIndex = lc.nearestSelectable(lc.Options.SelectedIndex, 1, false)
+	lc.rebuildSections()
+
+	return lc
 }
 
 func List(options ListOptions) (*ListResult, error) {
 	window := internal.GetWindow()
-	renderer := window.Renderer
 
 	if options.MaxVisibleItems <= 0 {
 		options.MaxVisibleItems = 9
@@ -139,115 +223,126 @@ func List(options ListOptions) (*ListResult, error) {
 
 	lc.Options.MaxVisibleItems = int(lc.calculateMaxVisibleItems(window))
 
-	if options.SelectedIndex > 0 {
-		lc.scrollTo(options.SelectedIndex)
+	lc.Options.VisibleStartIndex = max(min(lc.Options.VisibleStartIndex, len(lc.Options.Items)-lc.Options.MaxVisibleItems), 0)
+	if lc.Options.SelectedIndex > 0 {
+		lc.scrollTo(lc.Options.SelectedIndex)
 	}
+	lc.updatePinned()
 
-	running := true
-	cancelled := false
-	result := ListResult{
+	lc.result = ListResult{
 		Items:    lc.Options.Items,
 		Selected: []int{},
 		Action:   ListActionSelected,
 	}
 
-	for running {
-		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-			switch event.(type) {
-			case *sdl.QuitEvent:
-				running = false
-			case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
-				lc.handleInput(event, &running, &result, &cancelled)
-			case *sdl.WindowEvent:
-				we := event.(*sdl.WindowEvent)
-				if we.Event == sdl.WINDOWEVENT_RESIZED {
-					newMaxItems := lc.calculateMaxVisibleItems(window)
-					lc.Options.MaxVisibleItems = int(newMaxItems)
-					if lc.Options.SelectedIndex >= lc.Options.VisibleStartIndex+lc.Options.MaxVisibleItems {
-						lc.scrollTo(lc.Options.SelectedIndex)
-					}
-				}
-			}
-		}
+	RunScreen(lc, lc.screenOptions())
 
-		lc.handleDirectionalRepeats()
+	lc.closeDataSource()
+	lc.finishPinned()
+	lc.result.Items = lc.Options.Items
+	lc.finishFilter()
+	lc.saveState()
 
-		renderer.SetDrawColor(0, 0, 0, 255)
-		renderer.Clear()
-		renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
-
-		lc.render(window)
-		renderer.Present()
-		sdl.Delay(16)
+	if sort := lc.currentSort(); sort != nil {
+		lc.result.SortIndex = lc.Options.SortIndex
+		lc.result.SortName = sort.Name
 	}
 
-	if cancelled {
+	if lc.cancelled {
 		return nil, ErrCancelled
 	}
 
-	return &result, nil
+	return &lc.result, nil
 }
 
-func (lc *listController) handleInput(event interface{}, running *bool, result *ListResult, cancelled *bool) {
-	processor := internal.GetInputProcessor()
+func (lc *listController) screenOptions() ScreenOptions {
+	options := DefaultScreenOptions()
+	options.InputDelay = lc.Options.InputDelay
+	options.EnableHelp = lc.Options.EnableHelp
+	options.HelpTitle = lc.Options.HelpTitle
+	options.HelpText = lc.Options.HelpText
+	if lc.Options.EnableIndexRail {
+		options.RepeatButtons = append(options.RepeatButtons, lc.Options.PreviousSectionButton, lc.Options.NextSectionButton)
+	}
+	return options
+}
 
-	inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-	if inputEvent == nil {
+func (lc *listController) HandleInput(inputEvent *InputEvent) {
+	if !inputEvent.Pressed {
+		lc.handleContextRelease(inputEvent.Button)
+		lc.handleRangeRelease(inputEvent.Button)
 		return
 	}
 
-	if inputEvent.Pressed {
-		if lc.ShowingHelp {
-			lc.handleHelpInput(inputEvent.Button)
-			return
-		}
+	if lc.typing.active() {
+		lc.handleFilterTyping(inputEvent)
+		return
+	}
 
-		if lc.ReorderMode && !lc.isDirectionalInput(inputEvent.Button) {
-			lc.ReorderMode = false
-			return
-		}
+	if lc.contextMenu.open {
+		lc.handleContextMenuInput(inputEvent.Button)
+		return
+	}
 
-		if lc.handleNavigation(inputEvent.Button) {
-			return
-		}
+	if lc.ReorderMode && !lc.isDirectionalInput(inputEvent.Button) {
+		lc.ReorderMode = false
+		return
+	}
 
-		lc.handleActionButtons(inputEvent.Button, running, result, cancelled)
-	} else {
-		lc.handleInputEventRelease(inputEvent)
+	if lc.handleRangeInput(inputEvent.Button) {
+		return
 	}
-}
 
-func (lc *listController) handleHelpInput(button constants.VirtualButton) {
-	switch button {
-	case constants.VirtualButtonUp:
-		if lc.helpOverlay != nil {
-			lc.helpOverlay.scroll(-1)
-		}
-	case constants.VirtualButtonDown:
-		if lc.helpOverlay != nil {
-			lc.helpOverlay.scroll(1)
-		}
-	case constants.VirtualButtonMenu:
-		lc.ShowingHelp = false
-	default:
-		lc.ShowingHelp = false
+	if lc.handleFavoriteInput(inputEvent.Button) {
+		return
+	}
+
+	if lc.handleFilterInput(inputEvent.Button) {
+		return
+	}
+
+	if lc.handleSortInput(inputEvent.Button) {
+		return
 	}
+
+	if lc.handleSectionJump(inputEvent.Button) {
+		return
+	}
+
+	if lc.handleNavigation(inputEvent.Button) {
+		return
+	}
+
+	if lc.handleContextPress(inputEvent.Button) {
+		return
+	}
+
+	lc.handleActionButtons(inputEvent.Button)
 }
 
-func (lc *listController) handleInputEventRelease(inputEvent *internal.Event) {
-	switch inputEvent.Button {
-	case constants.VirtualButtonUp:
-		lc.heldDirections.up = false
-		lc.hasRepeated = false
-	case constants.VirtualButtonDown:
-		lc.heldDirections.down = false
-		lc.hasRepeated = false
-	case constants.VirtualButtonLeft:
-		lc.heldDirections.left = false
-		lc.hasRepeated = false
-	case constants.VirtualButtonRight:
-		lc.heldDirections.right = false
-		lc.hasRepeated = false
+func (lc *listController) Update() {
+	lc.applyHandleChanges()
+	lc.updatePinned()
+	lc.updateLongPress()
+	lc.updateDataSource()
+	lc.updateScrolling()
+	lc.typing.update()
+}
+
+func (lc *listController) Render(renderer *sdl.Renderer) {
+	lc.render(internal.GetWindow())
+	lc.renderContextMenu(renderer)
+	lc.typing.render(renderer)
+}
+
+func (lc *listController) Done() bool {
+	return lc.done
+}
+
+func (lc *listController) HandleResize(width, height int32) {
+	lc.Options.MaxVisibleItems = int(lc.maxVisibleItemsForHeight(height))
+	if lc.Options.SelectedIndex >= lc.Options.VisibleStartIndex+lc.Options.MaxVisibleItems {
+		lc.scrollTo(lc.Options.SelectedIndex)
 	}
 }
 
@@ -265,85 +360,70 @@ func (lc *listController) handleNavigation(button constants.VirtualButton) bool
 	switch button {
 	case constants.VirtualButtonUp:
 		direction = "up"
-		lc.heldDirections.up = true
-		lc.heldDirections.down = false
 	case constants.VirtualButtonDown:
 		direction = "down"
-		lc.heldDirections.down = true
-		lc.heldDirections.up = false
 	case constants.VirtualButtonLeft:
 		direction = "left"
-		lc.heldDirections.left = true
-		lc.heldDirections.right = false
 	case constants.VirtualButtonRight:
 		direction = "right"
-		lc.heldDirections.right = true
-		lc.heldDirections.left = false
 	default:
 	}
 
 	if direction != "" {
 		lc.navigate(direction)
-		lc.lastRepeatTime = time.Now()
 		return true
 	}
 	return false
 }
 
-func (lc *listController) handleActionButtons(button constants.VirtualButton, running *bool, result *ListResult, cancelled *bool) {
-	if len(lc.Options.Items) == 0 && button != constants.VirtualButtonB && button != constants.VirtualButtonMenu {
+func (lc *listController) handleActionButtons(button constants.VirtualButton) {
+	if len(lc.Options.Items) == 0 && button != constants.VirtualButtonB {
 		return
 	}
 
 	if button == constants.VirtualButtonA {
 		if lc.MultiSelect && len(lc.Options.Items) > 0 {
 			lc.toggleSelection(lc.Options.SelectedIndex)
-		} else if len(lc.Options.Items) > 0 {
-			*running = false
-			result.Action = ListActionSelected
-			result.Selected = []int{lc.Options.SelectedIndex}
-			result.VisiblePosition = lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
+		} else if lc.actionable(lc.Options.SelectedIndex) {
+			lc.done = true
+			lc.result.Action = ListActionSelected
+			lc.result.Selected = []int{lc.Options.SelectedIndex}
+			lc.result.VisiblePosition = lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
 		}
 	}
 
 	if button == constants.VirtualButtonB {
 		if !lc.Options.DisableBackButton {
-			*running = false
-			*cancelled = true
+			lc.done = true
+			lc.cancelled = true
 		}
 	}
 
 	if button == constants.VirtualButtonX {
 		if lc.Options.EnableAction {
-			*running = false
-			result.Action = ListActionTriggered
-			if len(lc.Options.Items) > 0 {
-				if lc.MultiSelect {
-					if indices := lc.getSelectedItems(); len(indices) > 0 {
-						result.Selected = indices
-						result.VisiblePosition = indices[0] - lc.Options.VisibleStartIndex
-					}
-				} else {
-					result.Selected = []int{lc.Options.SelectedIndex}
-					result.VisiblePosition = lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
+			if lc.MultiSelect {
+				lc.done = true
+				lc.result.Action = ListActionTriggered
+				if indices := lc.actionableSelection(); len(indices) > 0 {
+					lc.result.Selected = indices
+					lc.result.VisiblePosition = indices[0] - lc.Options.VisibleStartIndex
 				}
+			} else if lc.actionable(lc.Options.SelectedIndex) {
+				lc.done = true
+				lc.result.Action = ListActionTriggered
+				lc.result.Selected = []int{lc.Options.SelectedIndex}
+				lc.result.VisiblePosition = lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
 			}
 		}
 	}
 
-	if button == constants.VirtualButtonMenu {
-		if lc.Options.EnableHelp {
-			lc.ShowingHelp = !lc.ShowingHelp
-		}
-	}
-
 	if button == constants.VirtualButtonStart {
 		if lc.MultiSelect && len(lc.Options.Items) > 0 {
-			*running = false
-			result.Action = ListActionSelected
-			if indices := lc.getSelectedItems(); len(indices) > 0 {
-				result.Selected = indices
-				result.VisiblePosition = indices[0] - lc.Options.VisibleStartIndex
+			lc.done = true
+			lc.result.Action = ListActionSelected
+			if indices := lc.actionableSelection(); len(indices) > 0 {
+				lc.result.Selected = indices
+				lc.result.VisiblePosition = indices[0] - lc.Options.VisibleStartIndex
 			}
 		}
 	}
@@ -355,18 +435,14 @@ func (lc *listController) handleActionButtons(button constants.VirtualButton, ru
 	}
 
 	if button == lc.Options.ReorderButton {
-		if lc.Options.EnableReordering && len(lc.Options.Items) > 0 {
+		if lc.Options.EnableReordering && len(lc.Options.Items) > 0 && !lc.filter.active() && lc.data == nil {
+			lc.stripPinned()
 			lc.ReorderMode = !lc.ReorderMode
 		}
 	}
 }
 
 func (lc *listController) navigate(direction string) {
-	if time.Since(lc.lastInputTime) < lc.Options.InputDelay {
-		return
-	}
-	lc.lastInputTime = time.Now()
-
 	switch direction {
 	case "up":
 		if lc.ReorderMode {
@@ -435,6 +511,13 @@ func (lc *listController) moveSelection(delta int) {
 		}
 	}
 
+	// Skip headers, separators and disabled items, wrapping around for single steps
+	direction := 1
+	if delta < 0 {
+		direction = -1
+	}
+	newIndex = lc.nearestSelectable(newIndex, direction, delta == 1 || delta == -1)
+
 	lc.Options.SelectedIndex = newIndex
 	lc.scrollTo(newIndex)
 	lc.updateSelectionState()
@@ -504,6 +587,7 @@ func (lc *listController) moveItemOneStep(direction int) bool {
 
 	lc.Options.SelectedIndex = targetIndex
 	lc.scrollTo(targetIndex)
+	lc.rebuildSections()
 
 	if lc.Options.OnReorder != nil {
 		lc.Options.OnReorder(currentIndex, targetIndex)
@@ -513,6 +597,7 @@ func (lc *listController) moveItemOneStep(direction int) bool {
 }
 
 func (lc *listController) toggleMultiSelect() {
+	lc.stripPinned()
 	lc.MultiSelect = !lc.MultiSelect
 
 	if !lc.MultiSelect {
@@ -526,7 +611,7 @@ func (lc *listController) toggleMultiSelect() {
 }
 
 func (lc *listController) toggleSelection(index int) {
-	if index < 0 || index >= len(lc.Options.Items) || lc.Options.Items[index].NotMultiSelectable {
+	if index < 0 || index >= len(lc.Options.Items) || lc.Options.Items[index].NotMultiSelectable || !isSelectable(lc.Options.Items[index]) {
 		return
 	}
 
@@ -555,6 +640,28 @@ func (lc *listController) getSelectedItems() []int {
 	return indices
 }
 
+// actionable reports whether the item at index can be chosen with A, X or Start:
+// not a header, separator, disabled row or a placeholder still loading
+func (lc *listController) actionable(index int) bool {
+	if index < 0 || index >= len(lc.Options.Items) {
+		return false
+	}
+	item := lc.Options.Items[index]
+	return isSelectable(item) && !isPlaceholder(item)
+}
+
+// actionableSelection returns the selected indices that can be chosen, in ascending order
+func (lc *listController) actionableSelection() []int {
+	var indices []int
+	for _, idx := range lc.getSelectedItems() {
+		if lc.actionable(idx) {
+			indices = append(indices, idx)
+		}
+	}
+	slices.Sort(indices)
+	return indices
+}
+
 func (lc *listController) scrollTo(index int) {
 	if index < lc.Options.VisibleStartIndex {
 		lc.Options.VisibleStartIndex = index
@@ -566,40 +673,7 @@ func (lc *listController) scrollTo(index int) {
 	}
 }
 
-func (lc *listController) handleDirectionalRepeats() {
-	if len(lc.Options.Items) == 0 || (!lc.heldDirections.up && !lc.heldDirections.down && !lc.heldDirections.left && !lc.heldDirections.right) {
-		lc.lastRepeatTime = time.Now()
-		lc.hasRepeated = false
-		return
-	}
-
-	timeSince := time.Since(lc.lastRepeatTime)
-
-	// Use repeatDelay for first repeat, then repeatInterval for subsequent repeats
-	threshold := lc.repeatInterval
-	if !lc.hasRepeated {
-		threshold = lc.repeatDelay
-	}
-
-	if timeSince >= threshold {
-		lc.lastRepeatTime = time.Now()
-		lc.hasRepeated = true
-
-		if lc.heldDirections.up {
-			lc.navigate("up")
-		} else if lc.heldDirections.down {
-			lc.navigate("down")
-		} else if lc.heldDirections.left {
-			lc.navigate("left")
-		} else if lc.heldDirections.right {
-			lc.navigate("right")
-		}
-	}
-}
-
 func (lc *listController) render(window *internal.Window) {
-	lc.updateScrolling()
-
 	for i := range lc.Options.Items {
 		lc.Options.Items[i].Focused = i == lc.Options.SelectedIndex
 	}
@@ -615,12 +689,11 @@ func (lc *listController) render(window *internal.Window) {
 		}
 	}
 
-	lc.renderContent(window, visibleItems)
-
-	if lc.ShowingHelp && lc.helpOverlay != nil {
-		lc.helpOverlay.ShowingHelp = true
-		lc.helpOverlay.render(window.Renderer, internal.Fonts.SmallFont)
+	for i := range visibleItems {
+		visibleItems[i].Badges = lc.favoriteBadges(visibleItems[i])
 	}
+
+	lc.renderContent(window, visibleItems)
 }
 
 func (lc *listController) renderContent(window *internal.Window, visibleItems []MenuItem) {
@@ -639,25 +712,29 @@ func (lc *listController) renderContent(window *internal.Window, visibleItems []
 		window.RenderBackground()
 	}
 
-	if lc.Options.Title != "" {
+	if title := lc.displayTitle(); title != "" {
 		titleFont := internal.Fonts.ExtraLargeFont
 		if lc.Options.SmallTitle {
 			titleFont = internal.Fonts.LargeFont
 		}
-		itemStartY = lc.renderScrollableTitle(renderer, titleFont, lc.Options.Title, lc.Options.TitleAlign, lc.StartY, lc.Options.Margins.Left+10) + lc.Options.TitleSpacing
+		itemStartY = lc.renderScrollableTitle(renderer, titleFont, title, lc.Options.TitleAlign, lc.StartY, lc.Options.Margins.Left+10) + lc.Options.TitleSpacing
 	}
 
 	if len(lc.Options.Items) == 0 {
 		lc.renderEmptyMessage(renderer, internal.Fonts.MediumFont, itemStartY)
 	} else {
 		lc.renderItems(renderer, internal.Fonts.SmallFont, visibleItems, itemStartY)
+		lc.renderIndexRail(renderer, internal.Fonts.TinyFont, itemStartY)
 	}
 
 	if lc.imageIsDisplayed() {
 		lc.renderSelectedItemImage(renderer, lc.Options.Items[lc.Options.SelectedIndex].ImageFilename)
 	}
 
-	renderFooter(renderer, internal.Fonts.SmallFont, lc.Options.FooterHelpItems, lc.Options.Margins.Bottom, true)
+	lc.renderLetterJump(renderer, internal.Fonts.SmallFont)
+
+	RenderFooter(renderer, internal.Fonts.SmallFont, lc.Options.FooterHelpItems, lc.Options.Margins.Bottom, true)
+	lc.renderSelectedCount(renderer)
 }
 
 func (lc *listController) imageIsDisplayed() bool {
@@ -673,11 +750,11 @@ func (lc *listController) imageIsDisplayed() bool {
 func (lc *listController) renderItems(renderer *sdl.Renderer, font *ttf.Font, visibleItems []MenuItem, startY int32) {
 	scaleFactor := internal.GetScaleFactor()
 
-	pillHeight := int32(float32(60) * scaleFactor)
+	pillHeight := lc.rowHeight()
 	pillPadding := int32(float32(40) * scaleFactor)
 
 	screenWidth, _, _ := renderer.GetOutputSize()
-	availableWidth := screenWidth - lc.Options.Margins.Left - lc.Options.Margins.Right
+	availableWidth := screenWidth - lc.Options.Margins.Left - lc.Options.Margins.Right - lc.indexRailWidth()
 	if lc.imageIsDisplayed() {
 		availableWidth -= screenWidth / 7
 	}
@@ -693,9 +770,27 @@ func (lc *listController) renderItems(renderer *sdl.Renderer, font *ttf.Font, vi
 		itemY := startY + int32(i)*(pillHeight+lc.Options.ItemSpacing)
 		globalIndex := lc.Options.VisibleStartIndex + i
 
+		if section := lc.sectionHeader(globalIndex); section != "" {
+			lc.renderSectionHeader(renderer, internal.Fonts.MicroFont, section, itemY, availableWidth)
+		}
+
+		if item.Type != MenuItemTypeNormal {
+			lc.renderDivider(renderer, item, itemY, pillHeight, maxPillWidth)
+			continue
+		}
+
+		textWidth := maxTextWidth
+		trailingWidth := lc.trailingWidth(item)
+		if trailingWidth > 0 {
+			textWidth -= trailingWidth + pillPadding/2
+		}
+
 		if item.Selected || item.Focused {
 			_, bgColor := lc.getItemColors(item)
 			pillWidth := internal.Min32(maxPillWidth, lc.measureText(font, itemText)+pillPadding)
+			if trailingWidth > 0 {
+				pillWidth = maxPillWidth
+			}
 
 			pillRect := sdl.Rect{
 				X: lc.Options.Margins.Left,
@@ -706,17 +801,31 @@ func (lc *listController) renderItems(renderer *sdl.Renderer, font *ttf.Font, vi
 			internal.DrawRoundedRect(renderer, &pillRect, int32(float32(30)*scaleFactor), bgColor)
 		}
 
-		lc.renderItemText(renderer, font, itemText, item.Focused, globalIndex, itemY, pillHeight, maxTextWidth)
+		lc.renderItemText(renderer, font, itemText, item.Focused, globalIndex, itemY, lc.primaryTextHeight(item), textWidth)
+		lc.renderSubtitle(renderer, item, itemY, textWidth)
+		if trailingWidth > 0 {
+			lc.renderTrailing(renderer, item, lc.Options.Margins.Left+maxPillWidth-pillPadding/2, itemY, pillHeight)
+		}
 	}
 }
 
 func (lc *listController) renderItemText(renderer *sdl.Renderer, font *ttf.Font, text string, focused bool, globalIndex int, itemY, pillHeight, maxWidth int32) {
 	textColor := lc.getTextColor(focused)
+	if lc.Options.Items[globalIndex].Disabled {
+		textColor = lc.disabledTextColor()
+	}
 
 	if focused && lc.shouldScroll(font, text, maxWidth) {
+		scrollData := lc.getOrCreateScrollData(globalIndex, text, font, maxWidth)
+		lc.renderMatchHighlights(renderer, font, text, utf8.RuneCountInString(text), globalIndex, itemY, pillHeight, scrollData.ScrollOffset, maxWidth)
 		lc.renderScrollingText(renderer, font, text, textColor, globalIndex, itemY, pillHeight, maxWidth)
 	} else {
 		truncatedText := lc.truncateText(font, text, maxWidth)
+		shown := utf8.RuneCountInString(truncatedText)
+		if truncatedText != text {
+			shown -= utf8.RuneCountInString("...")
+		}
+		lc.renderMatchHighlights(renderer, font, text, shown, globalIndex, itemY, pillHeight, 0, maxWidth)
 		lc.renderStaticText(renderer, font, truncatedText, textColor, itemY, pillHeight)
 	}
 }
@@ -782,7 +891,7 @@ func (lc *listController) renderScrollingText(renderer *sdl.Renderer, font *ttf.
 }
 
 func (lc *listController) renderEmptyMessage(renderer *sdl.Renderer, font *ttf.Font, startY int32) {
-	lines := strings.Split(lc.Options.EmptyMessage, "\n")
+	lines := strings.Split(lc.emptyMessage(), "\n")
 	screenWidth, screenHeight, _ := renderer.GetOutputSize()
 
 	lineHeight := int32(25)
@@ -819,31 +928,30 @@ func (lc *listController) renderEmptyMessage(renderer *sdl.Renderer, font *ttf.F
 }
 
 func (lc *listController) renderSelectedItemBackground(window *internal.Window, imageFilename string) {
-	bgTexture, err := img.LoadTexture(window.Renderer, imageFilename)
-	if err != nil {
+	bgTexture, _ := internal.GetImageLoader().Texture(imageFilename, window.GetWidth(), window.GetHeight())
+	if bgTexture == nil {
+		window.RenderBackground()
 		return
 	}
-	defer bgTexture.Destroy()
 	window.Renderer.Copy(bgTexture, nil, &sdl.Rect{X: 0, Y: 0, W: window.GetWidth(), H: window.GetHeight()})
 }
 
 func (lc *listController) renderSelectedItemImage(renderer *sdl.Renderer, imageFilename string) {
-	texture, err := img.LoadTexture(renderer, imageFilename)
-	if err != nil {
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+	maxImageWidth := screenWidth / 3
+	maxImageHeight := screenHeight / 2
+
+	texture, _ := internal.GetImageLoader().Texture(imageFilename, maxImageWidth, maxImageHeight)
+	if texture == nil {
 		return
 	}
-	defer texture.Destroy()
 
 	_, _, textureWidth, textureHeight, _ := texture.Query()
-	screenWidth, screenHeight, _ := renderer.GetOutputSize()
 
 	if textureWidth == 0 || textureHeight == 0 {
 		return
 	}
 
-	maxImageWidth := screenWidth / 3
-	maxImageHeight := screenHeight / 2
-
 	scaleX := float32(maxImageWidth) / float32(textureWidth)
 	scaleY := float32(maxImageHeight) / float32(textureHeight)
 
@@ -997,14 +1105,19 @@ func (lc *listController) shouldScroll(font *ttf.Font, text string, maxWidth int
 }
 
 func (lc *listController) calculateMaxVisibleItems(window *internal.Window) int32 {
-	scaleFactor := internal.GetScaleFactor()
+	_, screenHeight, _ := window.Renderer.GetOutputSize()
+	return lc.maxVisibleItemsForHeight(screenHeight)
+}
 
-	pillHeight := int32(float32(60) * scaleFactor)
+// maxVisibleItemsForHeight returns how many rows fit on a screen screenHeight pixels tall
+func (lc *listController) maxVisibleItemsForHeight(screenHeight int32) int32 {
+	scaleFactor := internal.GetScaleFactor()
 
-	_, screenHeight, _ := window.Renderer.GetOutputSize()
+	lc.updateRowLayout()
+	pillHeight := lc.rowHeight()
 
 	var titleHeight int32 = 0
-	if lc.Options.Title != "" {
+	if lc.displayTitle() != "" {
 		if lc.Options.SmallTitle {
 			titleHeight = int32(float32(50) * scaleFactor)
 		} else {
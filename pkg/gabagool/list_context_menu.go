@@ -0,0 +1,186 @@
+package gabagool
+
+import (
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// ContextMenuItem is an action offered in a List item's context menu.
+// The ID of the chosen action is returned in ListResult.ContextAction.
+type ContextMenuItem struct {
+	ID   string
+	Text string
+}
+
+type contextMenuState struct {
+	open    bool
+	actions []ContextMenuItem
+	index   int
+	offset  int // First action shown when there are more than fit on screen
+
+	pressPending bool
+	pressStart   time.Time
+}
+
+// contextActions returns the actions for the focused item: its own ContextMenu, or the list's
+func (lc *listController) contextActions() []ContextMenuItem {
+	if len(lc.Options.Items) == 0 {
+		return nil
+	}
+	item := lc.Options.Items[lc.Options.SelectedIndex]
+	if !isSelectable(item) || isPlaceholder(item) {
+		return nil
+	}
+	if len(item.ContextMenu) > 0 {
+		return item.ContextMenu
+	}
+	return lc.Options.ContextMenu
+}
+
+func (lc *listController) openContextMenu() bool {
+	actions := lc.contextActions()
+	if len(actions) == 0 {
+		return false
+	}
+	lc.contextMenu.open = true
+	lc.contextMenu.actions = actions
+	lc.contextMenu.index = 0
+	lc.contextMenu.offset = 0
+	return true
+}
+
+// handleContextPress opens the menu with ContextMenuButton, or starts timing a long press of A.
+// It returns true when the press was consumed.
+func (lc *listController) handleContextPress(button constants.VirtualButton) bool {
+	if button == lc.Options.ContextMenuButton && button != constants.VirtualButtonUnassigned {
+		return lc.openContextMenu()
+	}
+
+	if button == constants.VirtualButtonA && lc.Options.ContextMenuLongPress > 0 && len(lc.contextActions()) > 0 {
+		// A acts on release instead, unless it is held long enough to open the menu
+		lc.contextMenu.pressPending = true
+		lc.contextMenu.pressStart = time.Now()
+		return true
+	}
+
+	return false
+}
+
+// handleContextRelease performs the regular A action for a press that did not become a long press
+func (lc *listController) handleContextRelease(button constants.VirtualButton) {
+	if button != constants.VirtualButtonA || !lc.contextMenu.pressPending {
+		return
+	}
+	lc.contextMenu.pressPending = false
+	lc.handleActionButtons(constants.VirtualButtonA)
+}
+
+// updateLongPress opens the menu once A has been held for ContextMenuLongPress
+func (lc *listController) updateLongPress() {
+	if !lc.contextMenu.pressPending || time.Since(lc.contextMenu.pressStart) < lc.Options.ContextMenuLongPress {
+		return
+	}
+	lc.contextMenu.pressPending = false
+	lc.openContextMenu()
+}
+
+func (lc *listController) handleContextMenuInput(button constants.VirtualButton) {
+	count := len(lc.contextMenu.actions)
+
+	switch button {
+	case constants.VirtualButtonUp:
+		lc.contextMenu.index = (lc.contextMenu.index - 1 + count) % count
+	case constants.VirtualButtonDown:
+		lc.contextMenu.index = (lc.contextMenu.index + 1) % count
+	case constants.VirtualButtonA:
+		lc.contextMenu.open = false
+		lc.done = true
+		lc.result.Action = ListActionContextMenu
+		lc.result.ContextAction = lc.contextMenu.actions[lc.contextMenu.index].ID
+		lc.result.Selected = []int{lc.Options.SelectedIndex}
+		lc.result.VisiblePosition = lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
+	case constants.VirtualButtonB, lc.Options.ContextMenuButton:
+		lc.contextMenu.open = false
+	}
+}
+
+// renderContextMenu draws the open menu centered over the dimmed list. When there are more actions
+// than fit on screen the box stops at the screen edges and the actions scroll to keep the focused one shown.
+func (lc *listController) renderContextMenu(renderer *sdl.Renderer) {
+	if !lc.contextMenu.open {
+		return
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	theme := internal.GetTheme()
+	font := internal.Fonts.SmallFont
+	titleFont := internal.Fonts.TinyFont
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+
+	renderer.SetDrawColor(0, 0, 0, 160)
+	renderer.FillRect(&sdl.Rect{X: 0, Y: 0, W: screenWidth, H: screenHeight})
+
+	padding := int32(float32(20) * scaleFactor)
+	rowHeight := int32(float32(60) * scaleFactor)
+	title := lc.Options.Items[lc.Options.SelectedIndex].Text
+	titleHeight := int32(titleFont.Height()) + padding/2
+
+	width := screenWidth * 2 / 5
+	for _, action := range lc.contextMenu.actions {
+		textWidth, _, _ := font.SizeUTF8(action.Text)
+		width = max(width, int32(textWidth)+padding*3)
+	}
+	width = min(width, screenWidth-padding*2)
+
+	count := len(lc.contextMenu.actions)
+	maxRows := max(int((screenHeight-padding*4-titleHeight)/rowHeight), 1)
+	rows := min(count, maxRows)
+	lc.scrollContextMenu(rows)
+
+	height := titleHeight + rowHeight*int32(rows) + padding*2
+	box := sdl.Rect{X: (screenWidth - width) / 2, Y: (screenHeight - height) / 2, W: width, H: height}
+	internal.DrawRoundedRect(renderer, &box, int32(float32(20)*scaleFactor), sdl.Color{R: 30, G: 30, B: 30, A: 240})
+
+	internal.RenderTextLine(renderer, titleFont, internal.TruncateToWidth(titleFont, title, width-padding*2),
+		theme.HintInfoColor, box.X+padding, box.Y+padding, titleHeight-padding/2, constants.TextAlignLeft)
+
+	rowsY := box.Y + padding + titleHeight
+	for row := range rows {
+		i := lc.contextMenu.offset + row
+		action := lc.contextMenu.actions[i]
+		rowY := rowsY + int32(row)*rowHeight
+		color := theme.ListTextColor
+
+		if i == lc.contextMenu.index {
+			color = theme.ListTextSelectedColor
+			pill := sdl.Rect{X: box.X + padding/2, Y: rowY, W: width - padding, H: rowHeight}
+			internal.DrawRoundedRect(renderer, &pill, int32(float32(30)*scaleFactor), theme.MainColor)
+		}
+
+		internal.RenderTextLine(renderer, font, internal.TruncateToWidth(font, action.Text, width-padding*3),
+			color, box.X+padding*3/2, rowY, rowHeight, constants.TextAlignLeft)
+	}
+
+	if rows < count {
+		trackHeight := rowHeight * int32(rows)
+		barWidth := max(int32(float32(6)*scaleFactor), 2)
+		barX := box.X + width - padding/2 + (padding/2-barWidth)/2
+		handleHeight := max(trackHeight*int32(rows)/int32(count), barWidth*2)
+		handleY := rowsY + (trackHeight-handleHeight)*int32(lc.contextMenu.offset)/int32(count-rows)
+		internal.DrawSmoothScrollbar(renderer, barX, handleY, barWidth, handleHeight, theme.HintInfoColor)
+	}
+}
+
+// scrollContextMenu moves the first shown action so the focused one is among the rows shown
+func (lc *listController) scrollContextMenu(rows int) {
+	menu := &lc.contextMenu
+	if menu.index < menu.offset {
+		menu.offset = menu.index
+	} else if menu.index >= menu.offset+rows {
+		menu.offset = menu.index - rows + 1
+	}
+	menu.offset = max(min(menu.offset, len(menu.actions)-rows), 0)
+}
@@ -0,0 +1,35 @@
+package gabagool
+
+import "testing"
+
+func TestScrollContextMenu(t *testing.T) {
+	tests := []struct {
+		name   string
+		count  int
+		rows   int
+		index  int
+		offset int
+		want   int
+	}{
+		{name: "all fit", count: 4, rows: 4, index: 3, want: 0},
+		{name: "focus below the shown rows", count: 12, rows: 5, index: 7, want: 3},
+		{name: "focus above the shown rows", count: 12, rows: 5, index: 2, offset: 6, want: 2},
+		{name: "focus already shown", count: 12, rows: 5, index: 6, offset: 4, want: 4},
+		{name: "wrapped to the last action", count: 12, rows: 5, index: 11, want: 7},
+		{name: "wrapped to the first action", count: 12, rows: 5, index: 0, offset: 7, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &listController{}
+			lc.contextMenu.actions = make([]ContextMenuItem, tt.count)
+			lc.contextMenu.index = tt.index
+			lc.contextMenu.offset = tt.offset
+
+			lc.scrollContextMenu(tt.rows)
+			if lc.contextMenu.offset != tt.want {
+				t.Errorf("offset = %d, want %d", lc.contextMenu.offset, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,261 @@
+package gabagool
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+// ListDataSource supplies the items of a List on demand instead of ListOptions.Items.
+// It is called from the UI thread every frame, so both methods must be cheap.
+type ListDataSource interface {
+	// Count is the number of items currently known. It may grow as pages load.
+	Count() int
+	// ItemAt returns the item at index, or false while it has not been loaded.
+	ItemAt(index int) (MenuItem, bool)
+}
+
+// PageLoader can be implemented by a ListDataSource whose items are fetched a page at a time.
+// List calls LoadPage from a background goroutine for each page scrolled into view that ItemAt
+// does not have yet, and for the page after Count while HasMore reports true.
+type PageLoader interface {
+	PageSize() int
+	LoadPage(ctx context.Context, page int) error
+	HasMore() bool
+}
+
+// pageRetryDelay is how long List waits before loading a page again after LoadPage failed
+const pageRetryDelay = 2 * time.Second
+
+// listPlaceholder marks the Metadata of rows whose item has not been loaded
+type listPlaceholder struct{}
+
+func isPlaceholder(item MenuItem) bool {
+	_, ok := item.Metadata.(listPlaceholder)
+	return ok
+}
+
+func (lc *listController) placeholderItem() MenuItem {
+	return MenuItem{
+		Text:               lc.Options.PlaceholderText,
+		NotMultiSelectable: true,
+		Metadata:           listPlaceholder{},
+	}
+}
+
+type pageResult struct {
+	page int
+	err  error
+}
+
+// listDataState tracks the pages a List has asked its data source for
+type listDataState struct {
+	source  ListDataSource
+	loader  PageLoader
+	ctx     context.Context
+	cancel  context.CancelFunc
+	loading map[int]bool
+	failed  map[int]time.Time
+	results chan pageResult
+}
+
+func newListDataState(source ListDataSource) *listDataState {
+	ctx, cancel := context.WithCancel(internal.AppContext())
+	loader, _ := source.(PageLoader)
+	return &listDataState{
+		source:  source,
+		loader:  loader,
+		ctx:     ctx,
+		cancel:  cancel,
+		loading: make(map[int]bool),
+		failed:  make(map[int]time.Time),
+		results: make(chan pageResult),
+	}
+}
+
+// requestPage starts loading page in the background unless it is already loading or recently failed
+func (d *listDataState) requestPage(page int) {
+	if d.loader == nil || d.loading[page] {
+		return
+	}
+	if failedAt, ok := d.failed[page]; ok && time.Since(failedAt) < pageRetryDelay {
+		return
+	}
+
+	d.loading[page] = true
+	go func() {
+		err := d.loader.LoadPage(d.ctx, page)
+		select {
+		case d.results <- pageResult{page: page, err: err}:
+		case <-d.ctx.Done():
+		}
+	}()
+}
+
+func (d *listDataState) drain() {
+	for {
+		select {
+		case result := <-d.results:
+			delete(d.loading, result.page)
+			if result.err != nil {
+				d.failed[result.page] = time.Now()
+				internal.GetInternalLogger().Error("Failed to load list page", "page", result.page, "error", result.err)
+			} else {
+				delete(d.failed, result.page)
+			}
+		default:
+			return
+		}
+	}
+}
+
+// updateDataSource grows Items to the source's Count, replaces placeholders on screen
+// with loaded items and requests the pages that are still missing.
+// It is paused while a filter is active, since Items is then the filtered view.
+func (lc *listController) updateDataSource() {
+	d := lc.data
+	if d == nil || lc.filter.active() {
+		return
+	}
+
+	d.drain()
+
+	count := d.source.Count()
+	changed := count != len(lc.Options.Items)
+	if changed {
+		lc.resizeItems(count)
+	}
+
+	// Look one screen ahead so the next page is ready before it is scrolled to
+	end := min(lc.Options.VisibleStartIndex+lc.Options.MaxVisibleItems*2, count)
+	for i := lc.Options.VisibleStartIndex; i < end; i++ {
+		if !isPlaceholder(lc.Options.Items[i]) {
+			continue
+		}
+		item, ok := d.source.ItemAt(i)
+		if !ok {
+			if d.loader != nil {
+				d.requestPage(i / max(d.loader.PageSize(), 1))
+			}
+			continue
+		}
+		item.Selected = lc.SelectedItems[i]
+		lc.Options.Items[i] = item
+		changed = true
+	}
+
+	// Rows grow when the first loaded item brings a subtitle, so fewer of them fit
+	if changed {
+		hadSubtitles := lc.hasSubtitles
+		lc.updateRowLayout()
+		if lc.hasSubtitles != hadSubtitles {
+			lc.Options.MaxVisibleItems = int(lc.calculateMaxVisibleItems(internal.GetWindow()))
+			lc.scrollTo(lc.Options.SelectedIndex)
+		}
+	}
+
+	if d.loader != nil && d.loader.HasMore() && end >= count {
+		d.requestPage(count / max(d.loader.PageSize(), 1))
+	}
+}
+
+func (lc *listController) resizeItems(count int) {
+	if count < len(lc.Options.Items) {
+		lc.Options.Items = lc.Options.Items[:count]
+		for idx := range lc.SelectedItems {
+			if idx >= count {
+				delete(lc.SelectedItems, idx)
+			}
+		}
+		if lc.Options.SelectedIndex >= count {
+			lc.Options.SelectedIndex = max(count-1, 0)
+			lc.scrollTo(lc.Options.SelectedIndex)
+		}
+	} else {
+		for len(lc.Options.Items) < count {
+			lc.Options.Items = append(lc.Options.Items, lc.placeholderItem())
+		}
+	}
+	lc.rebuildSections()
+}
+
+func (lc *listController) closeDataSource() {
+	if lc.data != nil {
+		lc.data.cancel()
+	}
+}
+
+// PagedDataSource is a ListDataSource that fetches items a page at a time, suited to infinite scroll
+// over an HTTP API. Fetch returns the items of a page and whether more pages follow it.
+type PagedDataSource struct {
+	pageSize int
+	fetch    func(ctx context.Context, page int) ([]MenuItem, bool, error)
+
+	mu     sync.RWMutex
+	items  []MenuItem
+	loaded []bool
+	more   bool
+}
+
+// NewPagedDataSource creates a PagedDataSource that starts out empty and loads page 0 when shown
+func NewPagedDataSource(pageSize int, fetch func(ctx context.Context, page int) ([]MenuItem, bool, error)) *PagedDataSource {
+	return &PagedDataSource{
+		pageSize: max(pageSize, 1),
+		fetch:    fetch,
+		more:     true,
+	}
+}
+
+func (p *PagedDataSource) Count() int {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return len(p.items)
+}
+
+func (p *PagedDataSource) ItemAt(index int) (MenuItem, bool) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if index < 0 || index >= len(p.items) || !p.loaded[index] {
+		return MenuItem{}, false
+	}
+	return p.items[index], true
+}
+
+func (p *PagedDataSource) PageSize() int {
+	return p.pageSize
+}
+
+func (p *PagedDataSource) HasMore() bool {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	return p.more
+}
+
+func (p *PagedDataSource) LoadPage(ctx context.Context, page int) error {
+	items, more, err := p.fetch(ctx, page)
+	if err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	start := page * p.pageSize
+	end := start + len(items)
+	for len(p.items) < end {
+		p.items = append(p.items, MenuItem{})
+		p.loaded = append(p.loaded, false)
+	}
+	copy(p.items[start:end], items)
+	for i := start; i < end; i++ {
+		p.loaded[i] = true
+	}
+
+	// Only the last page known decides whether the list continues
+	if end >= len(p.items) {
+		p.more = more
+	}
+	return nil
+}
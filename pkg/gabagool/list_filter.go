@@ -0,0 +1,521 @@
+package gabagool
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// FilterMode selects how the filter query is entered
+type FilterMode int
+
+const (
+	// FilterModeKeyboard shows the on-screen keyboard over the bottom of the list and narrows the list
+	// as the query is typed. Start or Enter keeps the filter; Y restores the query from before typing.
+	FilterModeKeyboard FilterMode = iota
+	// FilterModeLetterJump opens a strip of letters; Left/Right narrow the list to items starting with that letter
+	FilterModeLetterJump
+)
+
+// FilterMatch selects how a typed query is matched against item text
+type FilterMatch int
+
+const (
+	// FilterMatchSubstring keeps items containing the query, ignoring case
+	FilterMatchSubstring FilterMatch = iota
+	// FilterMatchFuzzy keeps items containing every character of the query in order, ignoring case
+	FilterMatchFuzzy
+)
+
+// filterLetters are the entries of the letter-jump strip. "#" matches items that do not start with a letter.
+var filterLetters = []string{"#", "A", "B", "C", "D", "E", "F", "G", "H", "I", "J", "K", "L", "M",
+	"N", "O", "P", "Q", "R", "S", "T", "U", "V", "W", "X", "Y", "Z"}
+
+// listFilter holds the narrowed view of a list. While it is active the controller's Items
+// are the matching items and indices maps each of them back to its position in source.
+type listFilter struct {
+	query   string
+	source  []MenuItem
+	indices []int
+	matches [][]int
+
+	jumpOpen     bool
+	jumpIndex    int
+	jumpPrevious string
+}
+
+func (f *listFilter) active() bool {
+	return f.source != nil
+}
+
+func (f *listFilter) highlights(index int) []int {
+	if !f.active() || index < 0 || index >= len(f.matches) {
+		return nil
+	}
+	return f.matches[index]
+}
+
+// handleFilterInput handles the filter button, the letter-jump strip and clearing the filter with B.
+// It returns true when the press was consumed.
+func (lc *listController) handleFilterInput(button constants.VirtualButton) bool {
+	if !lc.Options.EnableFilter {
+		return false
+	}
+
+	if lc.filter.jumpOpen {
+		return lc.handleLetterJumpInput(button)
+	}
+
+	switch button {
+	case lc.Options.FilterButton:
+		lc.stripPinned()
+		if lc.Options.FilterMode == FilterModeLetterJump {
+			lc.openLetterJump()
+		} else {
+			lc.openFilterKeyboard()
+		}
+		return true
+	case constants.VirtualButtonB:
+		if lc.filter.active() {
+			lc.clearFilter()
+			return true
+		}
+	}
+
+	return false
+}
+
+// filterTyping is the keyboard shown over the list while a FilterModeKeyboard query is typed.
+// It lives outside listFilter so re-applying the filter on every edit keeps it open.
+type filterTyping struct {
+	keyboard *virtualKeyboard
+	previous string
+}
+
+func (t *filterTyping) active() bool {
+	return t.keyboard != nil
+}
+
+func (t *filterTyping) update() {
+	if t.active() {
+		t.keyboard.updateCursorBlink()
+	}
+}
+
+// render draws the keyboard over the bottom half of the list, which keeps showing the matches above it
+func (t *filterTyping) render(renderer *sdl.Renderer) {
+	if !t.active() {
+		return
+	}
+
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+	top := t.keyboard.TextInputRect.Y - int32(float32(10)*internal.GetScaleFactor())
+	renderer.SetDrawColor(0, 0, 0, 230)
+	renderer.FillRect(&sdl.Rect{X: 0, Y: top, W: screenWidth, H: screenHeight - top})
+
+	font := internal.Fonts.SmallFont
+	t.keyboard.renderTextInput(renderer, font)
+	t.keyboard.renderKeys(renderer, font)
+	t.keyboard.renderSpecialKeys(renderer)
+}
+
+func (lc *listController) openFilterKeyboard() {
+	window := internal.GetWindow()
+	top := window.GetHeight() / 2
+
+	kb := createKeyboard(window.GetWidth(), window.GetHeight()-top)
+	kb.moveBy(top)
+	kb.TextBuffer = lc.filter.query
+	kb.CursorPosition = utf8.RuneCountInString(lc.filter.query)
+
+	lc.typing = filterTyping{keyboard: kb, previous: lc.filter.query}
+}
+
+// handleFilterTyping passes a press to the filter keyboard and re-filters the list whenever the query changes
+func (lc *listController) handleFilterTyping(inputEvent *InputEvent) {
+	kb := lc.typing.keyboard
+	finished := kb.handleInputEvent(inputEvent)
+
+	if finished && !kb.EnterPressed {
+		previous := lc.typing.previous
+		lc.typing = filterTyping{}
+		lc.applyFilter(previous)
+		return
+	}
+
+	if query := strings.TrimSpace(kb.TextBuffer); query != lc.filter.query {
+		lc.applyFilter(query)
+	}
+	if finished {
+		lc.typing = filterTyping{}
+	}
+}
+
+func (lc *listController) openLetterJump() {
+	lc.filter.jumpOpen = true
+	lc.filter.jumpPrevious = lc.filter.query
+
+	lc.filter.jumpIndex = 0
+	if len(lc.Options.Items) > 0 {
+		lc.filter.jumpIndex = letterIndex(lc.Options.Items[lc.Options.SelectedIndex].Text)
+	}
+	lc.applyFilter(filterLetters[lc.filter.jumpIndex])
+}
+
+func (lc *listController) handleLetterJumpInput(button constants.VirtualButton) bool {
+	switch button {
+	case constants.VirtualButtonLeft, constants.VirtualButtonRight:
+		delta := 1
+		if button == constants.VirtualButtonLeft {
+			delta = -1
+		}
+		lc.filter.jumpIndex = (lc.filter.jumpIndex + delta + len(filterLetters)) % len(filterLetters)
+		lc.applyFilter(filterLetters[lc.filter.jumpIndex])
+		return true
+	case constants.VirtualButtonB:
+		lc.filter.jumpOpen = false
+		lc.applyFilter(lc.filter.jumpPrevious)
+		return true
+	case lc.Options.FilterButton:
+		lc.filter.jumpOpen = false
+		return true
+	case constants.VirtualButtonA:
+		// Close the strip and let A select the focused item as usual
+		lc.filter.jumpOpen = false
+		return false
+	}
+	return false
+}
+
+// applyFilter narrows Items to those matching query, keeping focus on the same item when it still matches.
+// An empty query clears the filter.
+func (lc *listController) applyFilter(query string) {
+	if query == "" {
+		lc.clearFilter()
+		return
+	}
+
+	focused := lc.originalIndex(lc.Options.SelectedIndex)
+
+	if lc.filter.active() {
+		lc.syncFilteredItems()
+	} else {
+		lc.filter.source = lc.Options.Items
+	}
+
+	lc.filter.query = query
+	lc.filter.indices = lc.filter.indices[:0]
+	lc.filter.matches = lc.filter.matches[:0]
+
+	for i, item := range lc.filter.source {
+		if isPlaceholder(item) || item.Type != MenuItemTypeNormal {
+			continue
+		}
+		if positions, ok := lc.matchItem(item.Text, query); ok {
+			lc.filter.indices = append(lc.filter.indices, i)
+			lc.filter.matches = append(lc.filter.matches, positions)
+		}
+	}
+
+	items := make([]MenuItem, len(lc.filter.indices))
+	for i, idx := range lc.filter.indices {
+		items[i] = lc.filter.source[idx]
+	}
+	lc.Options.Items = items
+
+	position := 0
+	for i, idx := range lc.filter.indices {
+		if idx == focused {
+			position = i
+			break
+		}
+	}
+	lc.resetView(position)
+}
+
+// clearFilter restores the full list with focus on the item that was focused in the filtered view.
+func (lc *listController) clearFilter() {
+	if !lc.filter.active() {
+		return
+	}
+
+	lc.syncFilteredItems()
+	focused := lc.originalIndex(lc.Options.SelectedIndex)
+
+	lc.Options.Items = lc.filter.source
+	lc.filter = listFilter{}
+
+	if focused >= len(lc.Options.Items) {
+		focused = 0
+	}
+	lc.resetView(focused)
+}
+
+// resetView focuses index after Items has been replaced
+func (lc *listController) resetView(index int) {
+	lc.Options.SelectedIndex = index
+	lc.Options.VisibleStartIndex = 0
+	lc.itemScrollData = make(map[int]*internal.TextScrollData)
+	lc.titleScrollData = &internal.TextScrollData{}
+	lc.Options.MaxVisibleItems = int(lc.calculateMaxVisibleItems(internal.GetWindow()))
+	lc.scrollTo(index)
+	lc.rebuildSections()
+
+	if lc.MultiSelect {
+		lc.SelectedItems = make(map[int]bool)
+		for i := range lc.Options.Items {
+			if lc.Options.Items[i].Selected {
+				lc.SelectedItems[i] = true
+			}
+		}
+	} else if len(lc.Options.Items) > 0 {
+		lc.updateSelectionState()
+	}
+}
+
+// syncFilteredItems copies selection changes made in the filtered view back to the source items
+func (lc *listController) syncFilteredItems() {
+	for i, idx := range lc.filter.indices {
+		lc.filter.source[idx] = lc.Options.Items[i]
+	}
+}
+
+// originalIndex maps an index into the current view to an index into the unfiltered items
+func (lc *listController) originalIndex(index int) int {
+	if !lc.filter.active() || index < 0 || index >= len(lc.filter.indices) {
+		return index
+	}
+	return lc.filter.indices[index]
+}
+
+// finishFilter maps the result back to the unfiltered items once the list is done
+func (lc *listController) finishFilter() {
+	if !lc.filter.active() {
+		return
+	}
+
+	lc.syncFilteredItems()
+
+	if lc.MultiSelect && !lc.cancelled && lc.result.Action != ListActionContextMenu {
+		// Items selected before the filter narrowed the view are still selected
+		var selected []int
+		for i, item := range lc.filter.source {
+			if item.Selected && !item.NotMultiSelectable {
+				selected = append(selected, i)
+			}
+		}
+		if len(selected) > 0 {
+			lc.result.Selected = selected
+		}
+	} else {
+		for i, idx := range lc.result.Selected {
+			lc.result.Selected[i] = lc.originalIndex(idx)
+		}
+	}
+
+	lc.result.Items = lc.filter.source
+}
+
+func (lc *listController) matchItem(text, query string) ([]int, bool) {
+	if lc.Options.FilterMode == FilterModeLetterJump {
+		return matchLetter(text, query)
+	}
+	if lc.Options.FilterMatch == FilterMatchFuzzy {
+		return matchFuzzy(text, query)
+	}
+	return matchSubstring(text, query)
+}
+
+// matchSubstring returns the rune positions of the first case-insensitive occurrence of query in text
+func matchSubstring(text, query string) ([]int, bool) {
+	haystack := foldRunes(text)
+	needle := foldRunes(query)
+	if len(needle) == 0 {
+		return nil, true
+	}
+
+	for start := 0; start+len(needle) <= len(haystack); start++ {
+		found := true
+		for j := range needle {
+			if haystack[start+j] != needle[j] {
+				found = false
+				break
+			}
+		}
+		if found {
+			positions := make([]int, len(needle))
+			for j := range positions {
+				positions[j] = start + j
+			}
+			return positions, true
+		}
+	}
+	return nil, false
+}
+
+// matchFuzzy returns the rune positions of the characters of query found in order in text, ignoring case
+func matchFuzzy(text, query string) ([]int, bool) {
+	haystack := foldRunes(text)
+	needle := foldRunes(query)
+
+	positions := make([]int, 0, len(needle))
+	for i := 0; i < len(haystack) && len(positions) < len(needle); i++ {
+		if haystack[i] == needle[len(positions)] {
+			positions = append(positions, i)
+		}
+	}
+	return positions, len(positions) == len(needle)
+}
+
+// matchLetter reports whether text starts with letter, or with a non-letter when letter is "#"
+func matchLetter(text, letter string) ([]int, bool) {
+	if text == "" {
+		return nil, false
+	}
+	if filterLetters[letterIndex(text)] == strings.ToUpper(letter) {
+		return []int{0}, true
+	}
+	return nil, false
+}
+
+// letterIndex returns the position in filterLetters of the first character of text
+func letterIndex(text string) int {
+	r, _ := utf8.DecodeRuneInString(text)
+	r = unicode.ToUpper(r)
+	if r < 'A' || r > 'Z' {
+		return 0
+	}
+	return int(r-'A') + 1
+}
+
+func foldRunes(text string) []rune {
+	runes := []rune(text)
+	for i, r := range runes {
+		runes[i] = unicode.ToLower(r)
+	}
+	return runes
+}
+
+func (lc *listController) displayTitle() string {
+	title := lc.Options.Title
+	if sort := lc.sortTitle(); sort != "" {
+		title = strings.TrimSpace(title + " " + sort)
+	}
+	if lc.filter.query == "" {
+		return title
+	}
+	if title == "" {
+		return lc.filter.query
+	}
+	return fmt.Sprintf("%s: %s", title, lc.filter.query)
+}
+
+func (lc *listController) emptyMessage() string {
+	if lc.filter.active() && lc.Options.FilterEmptyMessage != "" {
+		return lc.Options.FilterEmptyMessage
+	}
+	if lc.data != nil && lc.data.loader != nil && lc.data.loader.HasMore() {
+		return lc.Options.PlaceholderText
+	}
+	return lc.Options.EmptyMessage
+}
+
+// renderMatchHighlights marks the matched characters of the item at globalIndex behind its text.
+// text is the full item text including any selection prefix, shown is how many of its runes are drawn
+// and scrollOffset is the horizontal scroll of the text in pixels.
+func (lc *listController) renderMatchHighlights(renderer *sdl.Renderer, font *ttf.Font, text string, shown int, globalIndex int, itemY, pillHeight, scrollOffset, maxWidth int32) {
+	positions := lc.filter.highlights(globalIndex)
+	if len(positions) == 0 {
+		return
+	}
+
+	runes := []rune(text)
+	prefix := len(runes) - utf8.RuneCountInString(lc.Options.Items[globalIndex].Text)
+	if prefix < 0 {
+		prefix = 0
+	}
+
+	textX := lc.Options.Margins.Left + int32(float32(20)*internal.GetScaleFactor())
+	height := int32(font.Height())
+	color := internal.GetTheme().PrimaryAccentColor
+	renderer.SetDrawColor(color.R, color.G, color.B, 120)
+
+	for _, position := range positions {
+		index := prefix + position
+		if index >= shown || index >= len(runes) {
+			continue
+		}
+
+		before, _, _ := font.SizeUTF8(string(runes[:index]))
+		through, _, _ := font.SizeUTF8(string(runes[:index+1]))
+
+		left := internal.Max32(int32(before)-scrollOffset, 0)
+		right := internal.Min32(int32(through)-scrollOffset, maxWidth)
+		if right <= left {
+			continue
+		}
+
+		renderer.FillRect(&sdl.Rect{
+			X: textX + left,
+			Y: itemY + (pillHeight-height)/2,
+			W: right - left,
+			H: height,
+		})
+	}
+}
+
+// renderLetterJump draws the letter strip above the footer while it is open
+func (lc *listController) renderLetterJump(renderer *sdl.Renderer, font *ttf.Font) {
+	if !lc.filter.jumpOpen {
+		return
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+
+	stripHeight := int32(float32(50) * scaleFactor)
+	footerHeight := int32(float32(50) * scaleFactor)
+	stripRect := sdl.Rect{
+		X: lc.Options.Margins.Left,
+		Y: screenHeight - lc.Options.Margins.Bottom - footerHeight - stripHeight,
+		W: screenWidth - lc.Options.Margins.Left - lc.Options.Margins.Right,
+		H: stripHeight,
+	}
+	internal.DrawRoundedRect(renderer, &stripRect, int32(float32(20)*scaleFactor), sdl.Color{R: 0, G: 0, B: 0, A: 200})
+
+	cellWidth := stripRect.W / int32(len(filterLetters))
+	theme := internal.GetTheme()
+
+	for i, letter := range filterLetters {
+		color := theme.ListTextColor
+		cellX := stripRect.X + int32(i)*cellWidth
+
+		if i == lc.filter.jumpIndex {
+			color = theme.ListTextSelectedColor
+			pill := sdl.Rect{X: cellX, Y: stripRect.Y, W: cellWidth, H: stripHeight}
+			internal.DrawRoundedRect(renderer, &pill, int32(float32(20)*scaleFactor), theme.MainColor)
+		}
+
+		surface, _ := font.RenderUTF8Blended(letter, color)
+		if surface == nil {
+			continue
+		}
+		texture, _ := renderer.CreateTextureFromSurface(surface)
+		if texture != nil {
+			renderer.Copy(texture, nil, &sdl.Rect{
+				X: cellX + (cellWidth-surface.W)/2,
+				Y: stripRect.Y + (stripHeight-surface.H)/2,
+				W: surface.W,
+				H: surface.H,
+			})
+			texture.Destroy()
+		}
+		surface.Free()
+	}
+}
@@ -0,0 +1,213 @@
+package gabagool
+
+import (
+	"slices"
+	"sync"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+type listChangeKind int
+
+const (
+	listChangeUpdate listChangeKind = iota
+	listChangeAppend
+	listChangeRemove
+	listChangeSet
+)
+
+type listChange struct {
+	kind  listChangeKind
+	index int
+	key   string
+	items []MenuItem
+}
+
+// ListHandle lets other goroutines change the items of a List while it is showing.
+// Changes are queued and applied by the render loop in the order they were made, with indices
+// referring to the unfiltered items as they are after every earlier change.
+// When ListOptions.Sorts is set the list keeps its items in sorted order, so an index is a position
+// in that order rather than in the items passed in; UpdateKey and RemoveKey find items by MenuItem.Key instead.
+// The focused item stays focused, and in place on screen, unless it is removed.
+// A handle is ignored when the list has a DataSource.
+type ListHandle struct {
+	mu      sync.Mutex
+	changes []listChange
+}
+
+// NewListHandle creates a handle to pass in ListOptions.Handle
+func NewListHandle() *ListHandle {
+	return &ListHandle{}
+}
+
+// Update replaces the item at index, keeping whether it is selected
+func (h *ListHandle) Update(index int, item MenuItem) {
+	h.queue(listChange{kind: listChangeUpdate, index: index, items: []MenuItem{item}})
+}
+
+// UpdateKey replaces the first item whose key matches, keeping whether it is selected.
+// Keys are MenuItem.Key, or Text when it is empty.
+func (h *ListHandle) UpdateKey(key string, item MenuItem) {
+	h.queue(listChange{kind: listChangeUpdate, index: -1, key: key, items: []MenuItem{item}})
+}
+
+// Append adds items to the end of the list
+func (h *ListHandle) Append(items ...MenuItem) {
+	h.queue(listChange{kind: listChangeAppend, items: slices.Clone(items)})
+}
+
+// Remove deletes the item at index
+func (h *ListHandle) Remove(index int) {
+	h.queue(listChange{kind: listChangeRemove, index: index})
+}
+
+// RemoveKey deletes the first item whose key matches
+func (h *ListHandle) RemoveKey(key string) {
+	h.queue(listChange{kind: listChangeRemove, index: -1, key: key})
+}
+
+// Set replaces every item. Focus moves to the first item with the same key as the focused one, if any.
+func (h *ListHandle) Set(items []MenuItem) {
+	h.queue(listChange{kind: listChangeSet, items: slices.Clone(items)})
+}
+
+func (h *ListHandle) queue(change listChange) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.changes = append(h.changes, change)
+}
+
+func (h *ListHandle) drain() []listChange {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	changes := h.changes
+	h.changes = nil
+	return changes
+}
+
+// applyHandleChanges applies the queued changes to the unfiltered items, re-applying any filter afterwards
+func (lc *listController) applyHandleChanges() {
+	if lc.Options.Handle == nil || lc.data != nil {
+		return
+	}
+
+	changes := lc.Options.Handle.drain()
+	if len(changes) == 0 {
+		return
+	}
+
+	lc.stripPinned()
+
+	items := lc.Options.Items
+	focused := lc.Options.SelectedIndex
+	if lc.filter.active() {
+		lc.syncFilteredItems()
+		items = lc.filter.source
+		focused = lc.originalIndex(lc.Options.SelectedIndex)
+	}
+
+	shifted := false
+	for _, change := range changes {
+		var moved bool
+		items, focused, moved = applyListChange(items, focused, change)
+		shifted = shifted || moved
+	}
+
+	items, focused, moved := lc.sortItems(items, focused)
+	shifted = shifted || moved
+
+	lc.replaceItems(items, focused, shifted)
+}
+
+// replaceItems swaps in a new set of unfiltered items, re-applying any filter, with focus on the item
+// at focused in the new items. The focused item keeps its place on screen.
+func (lc *listController) replaceItems(items []MenuItem, focused int, shifted bool) {
+	screenOffset := lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
+
+	if lc.filter.active() {
+		// Start the filter again from the new items, keeping the letter-jump strip as it was
+		filter := lc.filter
+		lc.filter = listFilter{}
+		lc.Options.Items = items
+		lc.Options.SelectedIndex = max(min(focused, len(items)-1), 0)
+		lc.applyFilter(filter.query)
+		lc.filter.jumpOpen, lc.filter.jumpIndex, lc.filter.jumpPrevious = filter.jumpOpen, filter.jumpIndex, filter.jumpPrevious
+	} else {
+		lc.Options.Items = items
+		lc.Options.SelectedIndex = lc.nearestSelectable(max(min(focused, len(items)-1), 0), 1, false)
+		if shifted {
+			lc.itemScrollData = make(map[int]*internal.TextScrollData)
+		}
+
+		hadSubtitles := lc.hasSubtitles
+		lc.updateRowLayout()
+		if lc.hasSubtitles != hadSubtitles {
+			lc.Options.MaxVisibleItems = int(lc.calculateMaxVisibleItems(internal.GetWindow()))
+		}
+
+		lc.rebuildSections()
+		lc.SelectedItems = make(map[int]bool)
+		for i := range lc.Options.Items {
+			if lc.Options.Items[i].Selected {
+				lc.SelectedItems[i] = true
+			}
+		}
+		if !lc.MultiSelect && len(lc.Options.Items) > 0 {
+			lc.updateSelectionState()
+		}
+	}
+
+	// Keep the focused item where it was on screen
+	maxStart := max(len(lc.Options.Items)-lc.Options.MaxVisibleItems, 0)
+	lc.Options.VisibleStartIndex = max(min(lc.Options.SelectedIndex-screenOffset, maxStart), 0)
+	lc.scrollTo(lc.Options.SelectedIndex)
+}
+
+// applyListChange returns the changed items, the new index of the focused item
+// and whether items moved to a different index.
+func applyListChange(items []MenuItem, focused int, change listChange) ([]MenuItem, int, bool) {
+	if change.key != "" {
+		change.index = slices.IndexFunc(items, func(item MenuItem) bool {
+			return itemKey(item) == change.key
+		})
+	}
+
+	switch change.kind {
+	case listChangeUpdate:
+		if change.index < 0 || change.index >= len(items) {
+			return items, focused, false
+		}
+		item := change.items[0]
+		item.Selected = items[change.index].Selected
+		items[change.index] = item
+		return items, focused, false
+
+	case listChangeAppend:
+		return append(items, change.items...), focused, false
+
+	case listChangeRemove:
+		if change.index < 0 || change.index >= len(items) {
+			return items, focused, false
+		}
+		items = slices.Delete(items, change.index, change.index+1)
+		if change.index < focused {
+			focused--
+		}
+		return items, focused, true
+
+	case listChangeSet:
+		key := ""
+		if focused >= 0 && focused < len(items) {
+			key = itemKey(items[focused])
+		}
+		newFocus := slices.IndexFunc(change.items, func(item MenuItem) bool {
+			return itemKey(item) == key
+		})
+		if newFocus < 0 {
+			newFocus = focused
+		}
+		return change.items, newFocus, true
+	}
+
+	return items, focused, false
+}
@@ -0,0 +1,138 @@
+package gabagool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestApplyListChange(t *testing.T) {
+	items := func(texts ...string) []MenuItem {
+		result := make([]MenuItem, len(texts))
+		for i, text := range texts {
+			result[i] = MenuItem{Text: text}
+		}
+		return result
+	}
+	texts := func(items []MenuItem) []string {
+		result := make([]string, len(items))
+		for i, item := range items {
+			result[i] = item.Text
+		}
+		return result
+	}
+
+	tests := []struct {
+		name        string
+		items       []MenuItem
+		focused     int
+		change      listChange
+		want        []string
+		wantFocused int
+		wantMoved   bool
+	}{
+		{
+			name:        "update",
+			items:       items("a", "b", "c"),
+			focused:     2,
+			change:      listChange{kind: listChangeUpdate, index: 1, items: items("B")},
+			want:        []string{"a", "B", "c"},
+			wantFocused: 2,
+		},
+		{
+			name:        "update out of range",
+			items:       items("a", "b"),
+			change:      listChange{kind: listChangeUpdate, index: 5, items: items("x")},
+			want:        []string{"a", "b"},
+			wantFocused: 0,
+		},
+		{
+			name:        "update by key",
+			items:       []MenuItem{{Text: "Mario", Key: "smb"}, {Text: "Zelda", Key: "loz"}},
+			change:      listChange{kind: listChangeUpdate, index: -1, key: "loz", items: items("Zelda II")},
+			want:        []string{"Mario", "Zelda II"},
+			wantFocused: 0,
+		},
+		{
+			name:        "update by missing key",
+			items:       items("a", "b"),
+			change:      listChange{kind: listChangeUpdate, index: -1, key: "z", items: items("x")},
+			want:        []string{"a", "b"},
+			wantFocused: 0,
+		},
+		{
+			name:        "append",
+			items:       items("a"),
+			change:      listChange{kind: listChangeAppend, items: items("b", "c")},
+			want:        []string{"a", "b", "c"},
+			wantFocused: 0,
+		},
+		{
+			name:        "remove before focus",
+			items:       items("a", "b", "c"),
+			focused:     2,
+			change:      listChange{kind: listChangeRemove, index: 0},
+			want:        []string{"b", "c"},
+			wantFocused: 1,
+			wantMoved:   true,
+		},
+		{
+			name:        "remove after focus",
+			items:       items("a", "b", "c"),
+			focused:     0,
+			change:      listChange{kind: listChangeRemove, index: 2},
+			want:        []string{"a", "b"},
+			wantFocused: 0,
+			wantMoved:   true,
+		},
+		{
+			name:        "remove by key",
+			items:       items("a", "b", "c"),
+			focused:     2,
+			change:      listChange{kind: listChangeRemove, index: -1, key: "a"},
+			want:        []string{"b", "c"},
+			wantFocused: 1,
+			wantMoved:   true,
+		},
+		{
+			name:        "set keeps focus on the same key",
+			items:       items("a", "b", "c"),
+			focused:     1,
+			change:      listChange{kind: listChangeSet, items: items("c", "b")},
+			want:        []string{"c", "b"},
+			wantFocused: 1,
+			wantMoved:   true,
+		},
+		{
+			name:        "set without the focused key",
+			items:       items("a", "b"),
+			focused:     1,
+			change:      listChange{kind: listChangeSet, items: items("x", "y", "z")},
+			want:        []string{"x", "y", "z"},
+			wantFocused: 1,
+			wantMoved:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, focused, moved := applyListChange(tt.items, tt.focused, tt.change)
+			if !slices.Equal(texts(got), tt.want) {
+				t.Errorf("items = %q, want %q", texts(got), tt.want)
+			}
+			if focused != tt.wantFocused {
+				t.Errorf("focused = %d, want %d", focused, tt.wantFocused)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("moved = %v, want %v", moved, tt.wantMoved)
+			}
+		})
+	}
+}
+
+func TestApplyListChangeKeepsSelection(t *testing.T) {
+	items := []MenuItem{{Text: "a", Selected: true}}
+	got, _, _ := applyListChange(items, 0, listChange{kind: listChangeUpdate, index: 0, items: []MenuItem{{Text: "A"}}})
+	if !got[0].Selected {
+		t.Error("update cleared the selection")
+	}
+}
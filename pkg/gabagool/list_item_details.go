@@ -0,0 +1,140 @@
+package gabagool
+
+import (
+	"slices"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// Badge is a small label or icon drawn at the right end of a list item
+type Badge struct {
+	Text  string
+	Icon  string    // Image file drawn instead of Text when set
+	Color sdl.Color // Background of a text badge; the theme's accent color when left zero
+}
+
+// rowHeight is the height of every row, taller when any item has a subtitle
+func (lc *listController) rowHeight() int32 {
+	height := int32(float32(60) * internal.GetScaleFactor())
+	if lc.hasSubtitles {
+		height += int32(internal.Fonts.TinyFont.Height())
+	}
+	return height
+}
+
+func (lc *listController) updateRowLayout() {
+	lc.hasSubtitles = slices.ContainsFunc(lc.Options.Items, func(item MenuItem) bool {
+		return item.Subtitle != ""
+	})
+}
+
+// primaryTextHeight is the part of the row the primary text is centered in
+func (lc *listController) primaryTextHeight(item MenuItem) int32 {
+	if item.Subtitle == "" {
+		return lc.rowHeight()
+	}
+	return lc.rowHeight() - int32(internal.Fonts.TinyFont.Height())
+}
+
+func (lc *listController) detailColor(focused bool) sdl.Color {
+	if focused {
+		return internal.GetTheme().ListTextSelectedColor
+	}
+	return internal.GetTheme().HintInfoColor
+}
+
+// trailingWidth measures the secondary text and badges drawn at the right end of the row
+func (lc *listController) trailingWidth(item MenuItem) int32 {
+	scaleFactor := internal.GetScaleFactor()
+	gap := int32(float32(8) * scaleFactor)
+
+	var width int32
+	if item.SecondaryText != "" {
+		w, _, _ := internal.Fonts.TinyFont.SizeUTF8(item.SecondaryText)
+		width += int32(w)
+	}
+	for _, badge := range item.Badges {
+		if width > 0 {
+			width += gap
+		}
+		width += lc.badgeWidth(badge)
+	}
+	return width
+}
+
+func (lc *listController) badgeHeight() int32 {
+	return int32(internal.Fonts.MicroFont.Height()) + int32(float32(8)*internal.GetScaleFactor())
+}
+
+func (lc *listController) badgeWidth(badge Badge) int32 {
+	height := lc.badgeHeight()
+	if badge.Icon != "" {
+		return height
+	}
+	w, _, _ := internal.Fonts.MicroFont.SizeUTF8(badge.Text)
+	return int32(w) + height/2
+}
+
+// renderTrailing draws the secondary text right-aligned to rightX, with the badges to its left
+func (lc *listController) renderTrailing(renderer *sdl.Renderer, item MenuItem, rightX, itemY, rowHeight int32) {
+	gap := int32(float32(8) * internal.GetScaleFactor())
+	x := rightX
+
+	if item.SecondaryText != "" {
+		width := lc.renderDetailText(renderer, internal.Fonts.TinyFont, item.SecondaryText, lc.detailColor(item.Focused), x, itemY, rowHeight, true)
+		x -= width + gap
+	}
+
+	height := lc.badgeHeight()
+	badgeY := itemY + (rowHeight-height)/2
+
+	for i := len(item.Badges) - 1; i >= 0; i-- {
+		badge := item.Badges[i]
+		width := lc.badgeWidth(badge)
+		rect := sdl.Rect{X: x - width, Y: badgeY, W: width, H: height}
+
+		if badge.Icon != "" {
+			if texture := ImageTexture(badge.Icon, width, height); texture != nil {
+				_, _, iconW, iconH, _ := texture.Query()
+				renderer.Copy(texture, nil, &sdl.Rect{X: rect.X + (width-iconW)/2, Y: rect.Y + (height-iconH)/2, W: iconW, H: iconH})
+			}
+		} else {
+			color := badge.Color
+			if color == (sdl.Color{}) {
+				color = internal.GetTheme().PrimaryAccentColor
+			}
+			internal.DrawRoundedRect(renderer, &rect, height/2, color)
+			lc.renderDetailText(renderer, internal.Fonts.MicroFont, badge.Text, internal.GetTheme().ListTextSelectedColor,
+				rect.X+height/4, badgeY, height, false)
+		}
+
+		x -= width + gap
+	}
+}
+
+// renderSubtitle draws the item's subtitle under its primary text
+func (lc *listController) renderSubtitle(renderer *sdl.Renderer, item MenuItem, itemY, maxWidth int32) {
+	if item.Subtitle == "" {
+		return
+	}
+
+	font := internal.Fonts.TinyFont
+	textX := lc.Options.Margins.Left + int32(float32(20)*internal.GetScaleFactor())
+	subtitleY := itemY + lc.primaryTextHeight(item) - int32(float32(8)*internal.GetScaleFactor())
+
+	lc.renderDetailText(renderer, font, internal.TruncateToWidth(font, item.Subtitle, maxWidth), lc.detailColor(item.Focused),
+		textX, subtitleY, int32(font.Height()), false)
+}
+
+// renderDetailText draws text vertically centered in the row, ending at x when rightAligned and starting at it otherwise.
+// It returns the width drawn.
+func (lc *listController) renderDetailText(renderer *sdl.Renderer, font *ttf.Font, text string, color sdl.Color, x, itemY, rowHeight int32, rightAligned bool) int32 {
+	align := constants.TextAlignLeft
+	if rightAligned {
+		align = constants.TextAlignRight
+	}
+	return internal.RenderTextLine(renderer, font, text, color, x, itemY, rowHeight, align)
+}
@@ -0,0 +1,87 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// MenuItemType controls how a List renders an item and whether it can be focused
+type MenuItemType int
+
+const (
+	MenuItemTypeNormal MenuItemType = iota
+	// MenuItemTypeHeader renders Text as a group heading that cannot be focused
+	MenuItemTypeHeader
+	// MenuItemTypeSeparator renders a divider line that cannot be focused
+	MenuItemTypeSeparator
+)
+
+// isSelectable reports whether an item can be focused, selected or toggled
+func isSelectable(item MenuItem) bool {
+	return item.Type == MenuItemTypeNormal && !item.Disabled
+}
+
+// nearestSelectable returns the first selectable index starting at index and stepping by direction.
+// With wrap the search continues from the other end of the list, otherwise it falls back to searching
+// the opposite direction. It returns index unchanged if no item is selectable.
+func (lc *listController) nearestSelectable(index, direction int, wrap bool) int {
+	count := len(lc.Options.Items)
+	if count == 0 || direction == 0 {
+		return index
+	}
+
+	for step := 0; step < count; step++ {
+		candidate := index + step*direction
+		if wrap {
+			candidate = ((candidate % count) + count) % count
+		} else if candidate < 0 || candidate >= count {
+			break
+		}
+		if isSelectable(lc.Options.Items[candidate]) {
+			return candidate
+		}
+	}
+
+	if !wrap {
+		for candidate := index - direction; candidate >= 0 && candidate < count; candidate -= direction {
+			if isSelectable(lc.Options.Items[candidate]) {
+				return candidate
+			}
+		}
+	}
+
+	return index
+}
+
+// renderDivider draws a header or separator item across the row
+func (lc *listController) renderDivider(renderer *sdl.Renderer, item MenuItem, itemY, rowHeight, width int32) {
+	scaleFactor := internal.GetScaleFactor()
+	color := internal.GetTheme().HintInfoColor
+	textX := lc.Options.Margins.Left + int32(float32(20)*scaleFactor)
+
+	lineY := itemY + rowHeight/2
+	lineX := lc.Options.Margins.Left
+
+	if item.Type == MenuItemTypeHeader && item.Text != "" {
+		font := internal.Fonts.TinyFont
+		textWidth := lc.renderDetailText(renderer, font, internal.TruncateToWidth(font, item.Text, width), color, textX, itemY, rowHeight, false)
+		lineX = textX + textWidth + int32(float32(12)*scaleFactor)
+	}
+
+	if lineX >= lc.Options.Margins.Left+width {
+		return
+	}
+
+	renderer.SetDrawColor(color.R, color.G, color.B, 120)
+	renderer.FillRect(&sdl.Rect{
+		X: lineX,
+		Y: lineY,
+		W: lc.Options.Margins.Left + width - lineX,
+		H: max(int32(2*scaleFactor), 1),
+	})
+}
+
+func (lc *listController) disabledTextColor() sdl.Color {
+	color := internal.GetTheme().ListTextColor
+	return sdl.Color{R: color.R / 2, G: color.G / 2, B: color.B / 2, A: color.A}
+}
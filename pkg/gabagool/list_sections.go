@@ -0,0 +1,229 @@
+package gabagool
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// listSection is a run of consecutive items sharing a section, starting at start
+type listSection struct {
+	name  string
+	label string
+	start int
+}
+
+// maxRailLabel is the most characters of a section name shown on the index rail
+const maxRailLabel = 3
+
+// sectionName is the group of the current sort, the item's Section,
+// or the first letter of its text (# for anything else)
+func (lc *listController) sectionName(item MenuItem) string {
+	if sort := lc.currentSort(); sort != nil && sort.GroupBy != nil {
+		return sort.GroupBy(item)
+	}
+	if item.Section != "" {
+		return item.Section
+	}
+	return filterLetters[letterIndex(item.Text)]
+}
+
+// rebuildSections recomputes the sections after Items has changed
+func (lc *listController) rebuildSections() {
+	lc.sections = lc.sections[:0]
+	for i, item := range lc.Options.Items {
+		name := lc.sectionName(item)
+		if i < len(lc.pinned) {
+			name = lc.pinned[i].group
+		}
+		if len(lc.sections) == 0 || lc.sections[len(lc.sections)-1].name != name {
+			lc.sections = append(lc.sections, listSection{name: name, start: i})
+		}
+	}
+
+	names := make([]string, len(lc.sections))
+	for i, section := range lc.sections {
+		names[i] = section.name
+	}
+	for i, label := range railLabels(names) {
+		lc.sections[i].label = label
+	}
+}
+
+// railLabels returns the shortest prefix of each name, up to maxRailLabel characters,
+// that tells it apart from the other names
+func railLabels(names []string) []string {
+	labels := make([]string, len(names))
+	for i, name := range names {
+		runes := []rune(name)
+		length := min(len(runes), 1)
+		for length < min(len(runes), maxRailLabel) && sharesPrefix(names, i, string(runes[:length])) {
+			length++
+		}
+		labels[i] = string(runes[:length])
+	}
+	return labels
+}
+
+// sharesPrefix reports whether a name other than names[index] starts with prefix
+func sharesPrefix(names []string, index int, prefix string) bool {
+	for i, name := range names {
+		if i != index && name != names[index] && strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// sectionAt returns the position in sections of the section containing index
+func (lc *listController) sectionAt(index int) int {
+	position := sort.Search(len(lc.sections), func(i int) bool {
+		return lc.sections[i].start > index
+	})
+	return max(position-1, 0)
+}
+
+// sectionHeader returns the name to show above the item at index when it starts a named section.
+// Sections made from first letters only show on the index rail.
+func (lc *listController) sectionHeader(index int) string {
+	if len(lc.sections) == 0 {
+		return ""
+	}
+	section := lc.sections[lc.sectionAt(index)]
+	if section.start != index || index < len(lc.pinned) {
+		return ""
+	}
+	if sort := lc.currentSort(); (sort != nil && sort.GroupBy != nil) || lc.Options.Items[index].Section != "" {
+		return section.name
+	}
+	return ""
+}
+
+// handleSectionJump moves to the previous or next section when the index rail is enabled.
+// It returns true when the press was consumed.
+func (lc *listController) handleSectionJump(button constants.VirtualButton) bool {
+	if !lc.Options.EnableIndexRail || len(lc.Options.Items) == 0 {
+		return false
+	}
+
+	switch button {
+	case lc.Options.PreviousSectionButton:
+		lc.jumpToSection(lc.sectionAt(lc.Options.SelectedIndex) - 1)
+		return true
+	case lc.Options.NextSectionButton:
+		lc.jumpToSection(lc.sectionAt(lc.Options.SelectedIndex) + 1)
+		return true
+	}
+	return false
+}
+
+// jumpToSection focuses the first item of the section and scrolls it to the top of the list
+func (lc *listController) jumpToSection(position int) {
+	if position < 0 || position >= len(lc.sections) {
+		return
+	}
+
+	start := lc.sections[position].start
+	lc.Options.SelectedIndex = lc.nearestSelectable(start, 1, false)
+	lc.Options.VisibleStartIndex = max(min(start, len(lc.Options.Items)-lc.Options.MaxVisibleItems), 0)
+	lc.updateSelectionState()
+}
+
+// indexRailWidth is the horizontal space reserved for the index rail
+func (lc *listController) indexRailWidth() int32 {
+	if !lc.Options.EnableIndexRail {
+		return 0
+	}
+	return int32(float32(40) * internal.GetScaleFactor())
+}
+
+// renderIndexRail draws the sections down the right edge with the current one highlighted
+func (lc *listController) renderIndexRail(renderer *sdl.Renderer, font *ttf.Font, startY int32) {
+	if !lc.Options.EnableIndexRail || len(lc.sections) == 0 {
+		return
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+	footerHeight := int32(float32(50) * scaleFactor)
+
+	railWidth := lc.indexRailWidth()
+	railX := screenWidth - lc.Options.Margins.Right - railWidth
+	railHeight := screenHeight - lc.Options.Margins.Bottom - footerHeight - startY
+	entryHeight := railHeight / int32(len(lc.sections))
+	if entryHeight <= 0 {
+		return
+	}
+
+	theme := internal.GetTheme()
+	current := lc.sectionAt(lc.Options.SelectedIndex)
+
+	for i, section := range lc.sections {
+		if section.label == "" {
+			continue
+		}
+
+		entryY := startY + int32(i)*entryHeight
+		color := theme.ListTextColor
+
+		if i == current {
+			color = theme.ListTextSelectedColor
+			pill := sdl.Rect{X: railX, Y: entryY, W: railWidth, H: entryHeight}
+			internal.DrawRoundedRect(renderer, &pill, min(railWidth, entryHeight)/2, theme.MainColor)
+		}
+
+		surface, _ := font.RenderUTF8Blended(section.label, color)
+		if surface == nil {
+			continue
+		}
+		texture, _ := renderer.CreateTextureFromSurface(surface)
+		if texture != nil {
+			// Longer labels are shrunk to fit the rail rather than clipped
+			width, height := surface.W, surface.H
+			if width > railWidth {
+				height = height * railWidth / width
+				width = railWidth
+			}
+			renderer.Copy(texture, nil, &sdl.Rect{
+				X: railX + (railWidth-width)/2,
+				Y: entryY + (entryHeight-height)/2,
+				W: width,
+				H: height,
+			})
+			texture.Destroy()
+		}
+		surface.Free()
+	}
+}
+
+// renderSectionHeader draws a divider along the top of the row that starts a named section,
+// with the section name at its right end. Headers take no row of their own and cannot be focused.
+func (lc *listController) renderSectionHeader(renderer *sdl.Renderer, font *ttf.Font, name string, itemY, width int32) {
+	color := internal.GetTheme().HintInfoColor
+
+	renderer.SetDrawColor(color.R, color.G, color.B, 160)
+	renderer.FillRect(&sdl.Rect{X: lc.Options.Margins.Left, Y: itemY, W: width, H: max(int32(internal.GetScaleFactor()*2), 1)})
+
+	surface, _ := font.RenderUTF8Blended(name, color)
+	if surface == nil {
+		return
+	}
+	defer surface.Free()
+
+	texture, _ := renderer.CreateTextureFromSurface(surface)
+	if texture == nil {
+		return
+	}
+	defer texture.Destroy()
+
+	renderer.Copy(texture, nil, &sdl.Rect{
+		X: lc.Options.Margins.Left + width - surface.W,
+		Y: itemY + 2,
+		W: surface.W,
+		H: surface.H,
+	})
+}
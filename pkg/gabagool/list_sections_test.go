@@ -0,0 +1,30 @@
+package gabagool
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestRailLabels(t *testing.T) {
+	tests := []struct {
+		name  string
+		names []string
+		want  []string
+	}{
+		{name: "letters", names: []string{"#", "A", "B"}, want: []string{"#", "A", "B"}},
+		{name: "distinct first letters", names: []string{"Arcade", "Puzzle", "Racing"}, want: []string{"A", "P", "R"}},
+		{name: "shared first letter", names: []string{"Sega", "SNK", "Sony"}, want: []string{"Se", "SN", "So"}},
+		{name: "capped length", names: []string{"Nintendo 64", "Nintendo DS"}, want: []string{"Nin", "Nin"}},
+		{name: "prefix of another name", names: []string{"PS", "PSP"}, want: []string{"PS", "PSP"}},
+		{name: "repeated name", names: []string{"Favorites", "Games", "Favorites"}, want: []string{"F", "G", "F"}},
+		{name: "empty name", names: []string{"", "A"}, want: []string{"", "A"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := railLabels(tt.names); !slices.Equal(got, tt.want) {
+				t.Errorf("railLabels(%q) = %q, want %q", tt.names, got, tt.want)
+			}
+		})
+	}
+}
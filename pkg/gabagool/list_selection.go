@@ -0,0 +1,144 @@
+package gabagool
+
+import (
+	"fmt"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// rangeSelection tracks RangeSelectButton while it is held in multi-select mode
+type rangeSelection struct {
+	held   bool
+	anchor int
+	base   map[int]bool // Selection when the range started; items leaving the range go back to it
+	value  bool         // Whether items in the range are selected or deselected
+}
+
+// handleRangeInput handles RangeSelectButton and the buttons pressed while it is held.
+// It returns true when the press was consumed.
+func (lc *listController) handleRangeInput(button constants.VirtualButton) bool {
+	if !lc.MultiSelect || lc.ReorderMode || lc.Options.RangeSelectButton == constants.VirtualButtonUnassigned {
+		return false
+	}
+
+	if button == lc.Options.RangeSelectButton {
+		lc.startRange()
+		return true
+	}
+
+	if !lc.rangeSelect.held {
+		return false
+	}
+
+	switch button {
+	case lc.Options.SelectAllButton:
+		lc.selectWhere(func(int) bool { return true })
+	case lc.Options.SelectNoneButton:
+		lc.selectWhere(func(int) bool { return false })
+	case lc.Options.InvertSelectionButton:
+		lc.selectWhere(func(i int) bool { return !lc.Options.Items[i].Selected })
+	default:
+		if !lc.handleNavigation(button) {
+			return false
+		}
+		lc.extendRange()
+		return true
+	}
+
+	lc.startRange()
+	return true
+}
+
+// handleRangeRelease ends the range when RangeSelectButton is let go
+func (lc *listController) handleRangeRelease(button constants.VirtualButton) {
+	if button == lc.Options.RangeSelectButton {
+		lc.rangeSelect = rangeSelection{}
+	}
+}
+
+// startRange anchors a range at the focused item. The range selects items when the anchor
+// is not selected and deselects them when it is, like swiping across a grid of photos.
+func (lc *listController) startRange() {
+	lc.rangeSelect = rangeSelection{
+		held:   true,
+		anchor: lc.Options.SelectedIndex,
+		base:   make(map[int]bool, len(lc.SelectedItems)),
+	}
+	for idx := range lc.SelectedItems {
+		lc.rangeSelect.base[idx] = true
+	}
+	if len(lc.Options.Items) > 0 {
+		lc.rangeSelect.value = !lc.Options.Items[lc.rangeSelect.anchor].Selected
+	}
+}
+
+// extendRange applies the range between the anchor and the focused item
+func (lc *listController) extendRange() {
+	if lc.rangeSelect.anchor >= len(lc.Options.Items) {
+		lc.startRange()
+	}
+
+	from := min(lc.rangeSelect.anchor, lc.Options.SelectedIndex)
+	to := max(lc.rangeSelect.anchor, lc.Options.SelectedIndex)
+
+	lc.selectWhere(func(i int) bool {
+		if i >= from && i <= to {
+			return lc.rangeSelect.value
+		}
+		return lc.rangeSelect.base[i]
+	})
+}
+
+// selectWhere sets the selection of every item that can be multi-selected.
+// While a filter is active only the matching items are changed.
+func (lc *listController) selectWhere(selected func(index int) bool) {
+	for i, item := range lc.Options.Items {
+		if item.NotMultiSelectable || !isSelectable(item) || isPlaceholder(item) {
+			continue
+		}
+
+		lc.Options.Items[i].Selected = selected(i)
+		if lc.Options.Items[i].Selected {
+			lc.SelectedItems[i] = true
+		} else {
+			delete(lc.SelectedItems, i)
+		}
+	}
+}
+
+// selectedCount counts the selected items, including those hidden by a filter
+func (lc *listController) selectedCount() int {
+	items := lc.Options.Items
+	if lc.filter.active() {
+		lc.syncFilteredItems()
+		items = lc.filter.source
+	}
+
+	count := 0
+	for _, item := range items {
+		if item.Selected && !item.NotMultiSelectable {
+			count++
+		}
+	}
+	return count
+}
+
+// renderSelectedCount draws the number of selected items centered in the footer while in multi-select mode
+func (lc *listController) renderSelectedCount(renderer *sdl.Renderer) {
+	if !lc.MultiSelect || lc.Options.SelectedCountFormat == "" {
+		return
+	}
+
+	scaleFactor := internal.GetScaleFactor()
+	font := internal.Fonts.SmallFont
+	screenWidth, screenHeight, _ := renderer.GetOutputSize()
+
+	text := fmt.Sprintf(lc.Options.SelectedCountFormat, lc.selectedCount())
+
+	footerY := screenHeight - lc.Options.Margins.Bottom - int32(float32(50)*scaleFactor)
+	footerHeight := int32(float32(60) * scaleFactor)
+
+	internal.RenderTextLine(renderer, font, text, lc.Options.FooterTextColor, screenWidth/2, footerY, footerHeight, constants.TextAlignCenter)
+}
@@ -0,0 +1,110 @@
+package gabagool
+
+import (
+	"cmp"
+	"slices"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+)
+
+// ListSort is a named ordering of a List that the user can switch to with ListOptions.SortButton.
+// Compare orders the items, keeping the given order for equal items. GroupBy, when set, puts
+// items in sections named by its result, shown with headers and used by the index rail.
+// Items are ordered by group name first and by Compare within each group.
+// Header and separator items stay where they are; only the items between them are sorted.
+type ListSort struct {
+	Name    string
+	Compare func(a, b MenuItem) int
+	GroupBy func(item MenuItem) string
+}
+
+func (lc *listController) currentSort() *ListSort {
+	if len(lc.Options.Sorts) == 0 || lc.data != nil {
+		return nil
+	}
+	return &lc.Options.Sorts[lc.Options.SortIndex]
+}
+
+// handleSortInput cycles to the next sort. It returns true when the press was consumed.
+func (lc *listController) handleSortInput(button constants.VirtualButton) bool {
+	if button != lc.Options.SortButton || len(lc.Options.Sorts) < 2 || lc.data != nil {
+		return false
+	}
+
+	lc.Options.SortIndex = (lc.Options.SortIndex + 1) % len(lc.Options.Sorts)
+	lc.resort()
+	return true
+}
+
+// resort orders the unfiltered items by the current sort, keeping the focused item focused
+func (lc *listController) resort() {
+	if lc.currentSort() == nil {
+		return
+	}
+
+	lc.stripPinned()
+	items := lc.Options.Items
+	focused := lc.Options.SelectedIndex
+	if lc.filter.active() {
+		lc.syncFilteredItems()
+		items = lc.filter.source
+		focused = lc.originalIndex(lc.Options.SelectedIndex)
+	}
+
+	items, focused, _ = lc.sortItems(items, focused)
+	lc.replaceItems(items, focused, true)
+}
+
+// sortItems returns a sorted copy of items, the new index of the item at focused and whether any item moved
+func (lc *listController) sortItems(items []MenuItem, focused int) ([]MenuItem, int, bool) {
+	sort := lc.currentSort()
+	if sort == nil {
+		return items, focused, false
+	}
+
+	order := make([]int, len(items))
+	for i := range order {
+		order[i] = i
+	}
+
+	compare := func(a, b int) int {
+		if sort.GroupBy != nil {
+			if c := cmp.Compare(sort.GroupBy(items[a]), sort.GroupBy(items[b])); c != 0 {
+				return c
+			}
+		}
+		if sort.Compare != nil {
+			return sort.Compare(items[a], items[b])
+		}
+		return 0
+	}
+
+	// Sort each run of items between typed rows on its own so headers keep their items
+	start := 0
+	for i := 0; i <= len(items); i++ {
+		if i < len(items) && items[i].Type == MenuItemTypeNormal {
+			continue
+		}
+		slices.SortStableFunc(order[start:i], compare)
+		start = i + 1
+	}
+
+	sorted := make([]MenuItem, len(items))
+	newFocus := focused
+	moved := false
+	for i, idx := range order {
+		sorted[i] = items[idx]
+		if idx == focused {
+			newFocus = i
+		}
+		moved = moved || idx != i
+	}
+	return sorted, newFocus, moved
+}
+
+func (lc *listController) sortTitle() string {
+	if sort := lc.currentSort(); sort != nil && sort.Name != "" {
+		return "(" + sort.Name + ")"
+	}
+	return ""
+}
@@ -0,0 +1,97 @@
+package gabagool
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestSortItems(t *testing.T) {
+	byText := func(a, b MenuItem) int { return strings.Compare(a.Text, b.Text) }
+	byLength := func(item MenuItem) string { return string(rune('0' + len(item.Text))) }
+	header := func(text string) MenuItem { return MenuItem{Text: text, Type: MenuItemTypeHeader} }
+
+	tests := []struct {
+		name        string
+		sort        ListSort
+		items       []MenuItem
+		focused     int
+		want        []string
+		wantFocused int
+		wantMoved   bool
+	}{
+		{
+			name:        "compare",
+			sort:        ListSort{Compare: byText},
+			items:       []MenuItem{{Text: "c"}, {Text: "a"}, {Text: "b"}},
+			focused:     0,
+			want:        []string{"a", "b", "c"},
+			wantFocused: 2,
+			wantMoved:   true,
+		},
+		{
+			name:        "stable for equal items",
+			sort:        ListSort{Compare: func(a, b MenuItem) int { return 0 }},
+			items:       []MenuItem{{Text: "b"}, {Text: "a"}},
+			focused:     1,
+			want:        []string{"b", "a"},
+			wantFocused: 1,
+		},
+		{
+			name:        "group by only",
+			sort:        ListSort{GroupBy: byLength},
+			items:       []MenuItem{{Text: "ccc"}, {Text: "a"}, {Text: "bb"}, {Text: "d"}},
+			want:        []string{"a", "d", "bb", "ccc"},
+			wantFocused: 3,
+			wantMoved:   true,
+		},
+		{
+			name:        "group by then compare",
+			sort:        ListSort{GroupBy: byLength, Compare: byText},
+			items:       []MenuItem{{Text: "zz"}, {Text: "b"}, {Text: "aa"}, {Text: "a"}},
+			focused:     2,
+			want:        []string{"a", "b", "aa", "zz"},
+			wantFocused: 2,
+			wantMoved:   true,
+		},
+		{
+			name:        "typed rows stay in place",
+			sort:        ListSort{Compare: byText},
+			items:       []MenuItem{header("Two"), {Text: "b"}, {Text: "a"}, {Type: MenuItemTypeSeparator}, header("One"), {Text: "d"}, {Text: "c"}},
+			focused:     5,
+			want:        []string{"Two", "a", "b", "", "One", "c", "d"},
+			wantFocused: 6,
+			wantMoved:   true,
+		},
+		{
+			name:        "no sort",
+			items:       []MenuItem{{Text: "b"}, {Text: "a"}},
+			want:        []string{"b", "a"},
+			wantFocused: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &listController{}
+			if tt.sort.Compare != nil || tt.sort.GroupBy != nil {
+				lc.Options.Sorts = []ListSort{tt.sort}
+			}
+
+			sorted, focused, moved := lc.sortItems(tt.items, tt.focused)
+			got := make([]string, len(sorted))
+			for i, item := range sorted {
+				got[i] = item.Text
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("items = %q, want %q", got, tt.want)
+			}
+			if focused != tt.wantFocused {
+				t.Errorf("focused = %d, want %d", focused, tt.wantFocused)
+			}
+			if moved != tt.wantMoved {
+				t.Errorf("moved = %v, want %v", moved, tt.wantMoved)
+			}
+		})
+	}
+}
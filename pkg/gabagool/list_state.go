@@ -0,0 +1,339 @@
+package gabagool
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"slices"
+	"sync"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+)
+
+// listState is what a List with a StateID remembers between runs.
+// Items are identified by their key; see MenuItem.Key.
+type listState struct {
+	SelectedIndex     int      `json:"selected_index"`
+	VisibleStartIndex int      `json:"visible_start_index"`
+	Focused           string   `json:"focused,omitempty"`
+	Favorites         []string `json:"favorites,omitempty"`
+	Recent            []string `json:"recent,omitempty"`
+}
+
+// listStateStore holds the state of every List with a StateID. States are kept in memory
+// for the life of the process and saved together to the file set with Options.ListStateFile.
+type listStateStore struct {
+	mu     sync.Mutex
+	path   string
+	loaded bool
+	lists  map[string]listState
+}
+
+var listStates = &listStateStore{}
+
+func (s *listStateStore) setPath(path string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.path = path
+	s.loaded = false
+}
+
+func (s *listStateStore) get(id string) (listState, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	state, ok := s.lists[id]
+	return state, ok
+}
+
+// put stores the state of a list and writes every state to disk.
+// Failures are logged so a full SD card never stops the UI.
+func (s *listStateStore) put(id string, state listState) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.load()
+	s.lists[id] = state
+
+	if s.path == "" {
+		return
+	}
+	if err := s.save(); err != nil {
+		internal.GetInternalLogger().Error("Failed to save list state", "path", s.path, "error", err)
+	}
+}
+
+func (s *listStateStore) load() {
+	if s.loaded {
+		return
+	}
+	s.loaded = true
+	if s.lists == nil {
+		s.lists = make(map[string]listState)
+	}
+	if s.path == "" {
+		return
+	}
+
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		if !errors.Is(err, os.ErrNotExist) {
+			internal.GetInternalLogger().Error("Failed to read list state", "path", s.path, "error", err)
+		}
+		return
+	}
+
+	var lists map[string]listState
+	if err := json.Unmarshal(data, &lists); err != nil {
+		internal.GetInternalLogger().Error("Failed to parse list state", "path", s.path, "error", err)
+		return
+	}
+	for id, state := range lists {
+		s.lists[id] = state
+	}
+}
+
+// save writes to a temporary file first so a power cut never leaves a half written file behind
+func (s *listStateStore) save() error {
+	data, err := json.MarshalIndent(s.lists, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to marshal list state to JSON: %w", err)
+	}
+
+	tmpPath := s.path + ".tmp"
+	if err := os.WriteFile(tmpPath, data, 0644); err != nil {
+		return fmt.Errorf("failed to write JSON file: %w", err)
+	}
+
+	if err := os.Rename(tmpPath, s.path); err != nil {
+		return fmt.Errorf("failed to replace JSON file: %w", err)
+	}
+
+	return nil
+}
+
+// pinnedItem is a row of the Favorites and Recent groups at the top of the list.
+// source is the index of the item it repeats, or -1 for the group's header and the closing separator.
+type pinnedItem struct {
+	source int
+	group  string
+}
+
+func itemKey(item MenuItem) string {
+	if item.Key != "" {
+		return item.Key
+	}
+	return item.Text
+}
+
+// indexOfKey returns the index of the first item with key after the pinned groups, or -1
+func (lc *listController) indexOfKey(key string) int {
+	for i := len(lc.pinned); i < len(lc.Options.Items); i++ {
+		item := lc.Options.Items[i]
+		if !isPlaceholder(item) && itemKey(item) == key {
+			return i - len(lc.pinned)
+		}
+	}
+	return -1
+}
+
+// restoreState loads the saved state for StateID and focuses the item that was focused last time,
+// keeping its place on screen. The saved index is used when the item cannot be found by its key.
+func (lc *listController) restoreState() {
+	if lc.Options.StateID == "" {
+		return
+	}
+
+	state, ok := listStates.get(lc.Options.StateID)
+	lc.state = &state
+	lc.favorites = make(map[string]bool, len(state.Favorites))
+	for _, key := range state.Favorites {
+		lc.favorites[key] = true
+	}
+	lc.pinnedStale = true
+
+	if !ok {
+		return
+	}
+
+	index := state.SelectedIndex
+	if state.Focused != "" {
+		if found := lc.indexOfKey(state.Focused); found >= 0 {
+			index = found
+		}
+	}
+	if index < 0 || index >= len(lc.Options.Items) {
+		return
+	}
+
+	lc.Options.SelectedIndex = index
+	lc.Options.VisibleStartIndex = max(index-(state.SelectedIndex-state.VisibleStartIndex), 0)
+}
+
+// saveState records where the list was left and, when a single item was chosen, adds it to the recent items
+func (lc *listController) saveState() {
+	if lc.state == nil {
+		return
+	}
+
+	focused := lc.originalIndex(lc.Options.SelectedIndex)
+	lc.state.SelectedIndex = focused
+	lc.state.VisibleStartIndex = max(focused-(lc.Options.SelectedIndex-lc.Options.VisibleStartIndex), 0)
+	lc.state.Focused = ""
+	if focused >= 0 && focused < len(lc.result.Items) && !isPlaceholder(lc.result.Items[focused]) {
+		lc.state.Focused = itemKey(lc.result.Items[focused])
+	}
+
+	if lc.Options.EnableRecent && !lc.cancelled && len(lc.result.Selected) == 1 && lc.result.Selected[0] < len(lc.result.Items) {
+		if item := lc.result.Items[lc.result.Selected[0]]; !isPlaceholder(item) {
+			key := itemKey(item)
+			recent := slices.DeleteFunc(slices.Clone(lc.state.Recent), func(k string) bool { return k == key })
+			lc.state.Recent = slices.Insert(recent, 0, key)
+			if lc.Options.RecentLimit > 0 && len(lc.state.Recent) > lc.Options.RecentLimit {
+				lc.state.Recent = lc.state.Recent[:lc.Options.RecentLimit]
+			}
+		}
+	}
+
+	listStates.put(lc.Options.StateID, *lc.state)
+}
+
+// handleFavoriteInput stars or unstars the focused item. It returns true when the press was consumed.
+func (lc *listController) handleFavoriteInput(button constants.VirtualButton) bool {
+	if lc.state == nil || !lc.Options.EnableFavorites || button != lc.Options.FavoriteButton ||
+		lc.MultiSelect || lc.ReorderMode || lc.data != nil || len(lc.Options.Items) == 0 {
+		return false
+	}
+
+	item := lc.Options.Items[lc.Options.SelectedIndex]
+	if !isSelectable(item) || isPlaceholder(item) {
+		return false
+	}
+
+	key := itemKey(item)
+	if lc.favorites[key] {
+		delete(lc.favorites, key)
+		lc.state.Favorites = slices.DeleteFunc(slices.Clone(lc.state.Favorites), func(k string) bool { return k == key })
+	} else {
+		lc.favorites[key] = true
+		lc.state.Favorites = append(slices.Clone(lc.state.Favorites), key)
+	}
+	listStates.put(lc.Options.StateID, *lc.state)
+
+	lc.stripPinned()
+	lc.updatePinned()
+	return true
+}
+
+func (lc *listController) pinnedWanted() bool {
+	return lc.state != nil && (lc.Options.EnableFavorites || lc.Options.EnableRecent) &&
+		lc.data == nil && !lc.filter.active() && !lc.MultiSelect && !lc.ReorderMode
+}
+
+// updatePinned adds the Favorites and Recent groups to the top of the list when they are missing.
+// The groups are left out while filtering, multi-selecting or reordering. The rows on screen stay
+// where they are, unless the list is scrolled to the top where the groups are shown above them.
+func (lc *listController) updatePinned() {
+	if !lc.pinnedStale || !lc.pinnedWanted() {
+		return
+	}
+	lc.pinnedStale = false
+
+	var items []MenuItem
+	var pinned []pinnedItem
+	addGroup := func(title string, keys []string) {
+		var group []int
+		for _, key := range keys {
+			if index := lc.indexOfKey(key); index >= 0 && isSelectable(lc.Options.Items[index]) {
+				group = append(group, index)
+			}
+		}
+		if len(group) == 0 {
+			return
+		}
+
+		items = append(items, MenuItem{Text: title, Type: MenuItemTypeHeader})
+		pinned = append(pinned, pinnedItem{source: -1, group: title})
+		for _, index := range group {
+			item := lc.Options.Items[index]
+			item.Selected = false
+			items = append(items, item)
+			pinned = append(pinned, pinnedItem{source: index, group: title})
+		}
+	}
+
+	if lc.Options.EnableFavorites {
+		addGroup(lc.Options.FavoritesTitle, lc.state.Favorites)
+	}
+	if lc.Options.EnableRecent {
+		addGroup(lc.Options.RecentTitle, lc.state.Recent)
+	}
+	if len(pinned) == 0 {
+		return
+	}
+	items = append(items, MenuItem{Type: MenuItemTypeSeparator})
+	pinned = append(pinned, pinnedItem{source: -1, group: pinned[len(pinned)-1].group})
+
+	count := len(pinned)
+	lc.pinned = pinned
+	lc.Options.Items = append(items, lc.Options.Items...)
+	lc.Options.SelectedIndex += count
+	if lc.Options.VisibleStartIndex > 0 {
+		lc.Options.VisibleStartIndex += count
+	}
+	lc.pinnedChanged()
+}
+
+// stripPinned removes the Favorites and Recent groups, moving focus from a pinned row to the item it repeats
+func (lc *listController) stripPinned() {
+	lc.pinnedStale = true
+	count := len(lc.pinned)
+	if count == 0 {
+		return
+	}
+
+	screenOffset := lc.Options.SelectedIndex - lc.Options.VisibleStartIndex
+	focused := lc.Options.SelectedIndex - count
+	if lc.Options.SelectedIndex < count {
+		focused = max(lc.pinned[lc.Options.SelectedIndex].source, 0)
+	}
+
+	lc.Options.Items = lc.Options.Items[count:]
+	lc.pinned = nil
+	lc.Options.SelectedIndex = focused
+	maxStart := max(len(lc.Options.Items)-lc.Options.MaxVisibleItems, 0)
+	lc.Options.VisibleStartIndex = max(min(focused-screenOffset, maxStart), 0)
+	lc.pinnedChanged()
+}
+
+func (lc *listController) pinnedChanged() {
+	lc.itemScrollData = make(map[int]*internal.TextScrollData)
+	lc.rebuildSections()
+	lc.updateSelectionState()
+	lc.scrollTo(lc.Options.SelectedIndex)
+}
+
+// finishPinned maps results on pinned rows to the items they repeat and removes the groups
+func (lc *listController) finishPinned() {
+	count := len(lc.pinned)
+	selected := lc.result.Selected[:0]
+	for _, index := range lc.result.Selected {
+		if index >= count {
+			selected = append(selected, index-count)
+		} else if source := lc.pinned[index].source; source >= 0 {
+			// Group headers of the pinned rows have no source item
+			selected = append(selected, source)
+		}
+	}
+	lc.result.Selected = selected
+	lc.stripPinned()
+}
+
+// favoriteBadges returns the item's badges with FavoriteBadge first when the item is starred
+func (lc *listController) favoriteBadges(item MenuItem) []Badge {
+	if !lc.Options.EnableFavorites || !lc.favorites[itemKey(item)] || !isSelectable(item) || isPlaceholder(item) {
+		return item.Badges
+	}
+	return append([]Badge{lc.Options.FavoriteBadge}, item.Badges...)
+}
@@ -0,0 +1,83 @@
+package gabagool
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+)
+
+func TestListActionSkipsUnselectableItems(t *testing.T) {
+	items := []MenuItem{
+		{Text: "Header", Type: MenuItemTypeHeader},
+		{Text: "Game"},
+		{Text: "Locked", Disabled: true},
+		{Text: "Loading", Metadata: listPlaceholder{}},
+		{Type: MenuItemTypeSeparator},
+	}
+
+	tests := []struct {
+		name        string
+		button      constants.VirtualButton
+		multiSelect bool
+		focused     int
+		selected    []int
+		wantDone    bool
+		want        []int
+	}{
+		{name: "X on an item", button: constants.VirtualButtonX, focused: 1, wantDone: true, want: []int{1}},
+		{name: "X on a header", button: constants.VirtualButtonX, focused: 0},
+		{name: "X on a disabled item", button: constants.VirtualButtonX, focused: 2},
+		{name: "X on a placeholder", button: constants.VirtualButtonX, focused: 3},
+		{name: "A on a separator", button: constants.VirtualButtonA, focused: 4},
+		{
+			name:        "X in multi-select drops unselectable rows",
+			button:      constants.VirtualButtonX,
+			multiSelect: true,
+			selected:    []int{4, 1, 0, 2},
+			wantDone:    true,
+			want:        []int{1},
+		},
+		{
+			name:        "Start in multi-select drops unselectable rows",
+			button:      constants.VirtualButtonStart,
+			multiSelect: true,
+			selected:    []int{3, 1},
+			wantDone:    true,
+			want:        []int{1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lc := &listController{MultiSelect: tt.multiSelect, SelectedItems: make(map[int]bool)}
+			lc.Options.Items = slices.Clone(items)
+			lc.Options.EnableAction = true
+			lc.Options.DisableBackButton = true
+			lc.Options.SelectedIndex = tt.focused
+			for _, index := range tt.selected {
+				lc.SelectedItems[index] = true
+			}
+
+			lc.handleActionButtons(tt.button)
+			if lc.done != tt.wantDone {
+				t.Fatalf("done = %v, want %v", lc.done, tt.wantDone)
+			}
+			if !slices.Equal(lc.result.Selected, tt.want) {
+				t.Errorf("Selected = %v, want %v", lc.result.Selected, tt.want)
+			}
+		})
+	}
+}
+
+func TestFinishPinnedDropsGroupHeaders(t *testing.T) {
+	lc := &listController{}
+	lc.pinned = []pinnedItem{{source: -1, group: "Favorites"}, {source: 4, group: "Favorites"}}
+	lc.Options.Items = []MenuItem{{Text: "Favorites", Type: MenuItemTypeHeader}, {Text: "Zelda"}, {Text: "Mario"}}
+	lc.result.Selected = []int{0, 1, 2}
+
+	lc.finishPinned()
+	if want := []int{4, 0}; !slices.Equal(lc.result.Selected, want) {
+		t.Errorf("Selected = %v, want %v", lc.result.Selected, want)
+	}
+}
@@ -2,12 +2,20 @@ package gabagool
 
 type MenuItem struct {
 	Text               string
+	Key                string // Identifies the item in a List's saved favorites and recents and in ListHandle.UpdateKey; Text when empty
+	SecondaryText      string // Shown right-aligned, after any Badges
+	Subtitle           string // Shown in a smaller font under Text
+	Badges             []Badge
 	Selected           bool
 	Focused            bool
 	NotMultiSelectable bool
+	Disabled           bool         // Rendered dimmed and skipped when moving the selection
+	Type               MenuItemType // Headers and separators group items and cannot be focused
 	Metadata           interface{}
 	ImageFilename      string
 	BackgroundFilename string
+	Section            string            // Groups consecutive items under a header; see ListOptions.EnableIndexRail
+	ContextMenu        []ContextMenuItem // Replaces ListOptions.ContextMenu for this item
 }
 
 // ListResult is the standardized return type for the List component
@@ -16,4 +24,7 @@ type ListResult struct {
 	Selected        []int      // Indices of selected items (always a slice, even for single selection)
 	Action          ListAction // The action taken when exiting (Selected or Triggered)
 	VisiblePosition int        // Position of first selected item relative to VisibleStartIndex (for scroll restoration)
+	SortIndex       int        // Index into ListOptions.Sorts of the sort in use when exiting
+	SortName        string     // Name of the sort in use when exiting
+	ContextAction   string     // ID of the chosen ContextMenuItem when Action is ListActionContextMenu
 }
@@ -90,27 +90,20 @@ type optionsListController struct {
 	SelectedIndex int
 	Settings      internalOptionsListSettings
 	StartY        int32
-	lastInputTime time.Time
 	OnSelect      func(index int, item *ItemWithOptions)
 
 	VisibleStartIndex int
 	MaxVisibleItems   int
 
 	HelpEnabled bool
-	helpOverlay *helpOverlay
-	ShowingHelp bool
 
 	itemScrollData       map[int]*internal.TextScrollData
 	showingColorPicker   bool
 	activeColorPickerIdx int
 
-	heldDirections struct {
-		up, down, left, right bool
-	}
-	lastRepeatTime time.Time
-	repeatDelay    time.Duration
-	repeatInterval time.Duration
-	hasRepeated    bool
+	result    OptionsListResult
+	done      bool
+	cancelled bool
 }
 
 func defaultOptionsListSettings(title string) internalOptionsListSettings {
@@ -191,13 +184,9 @@ func newOptionsListController(title string, items []ItemWithOptions) *optionsLis
 		SelectedIndex:        selectedIndex,
 		Settings:             defaultOptionsListSettings(title),
 		StartY:               20,
-		lastInputTime:        time.Now(),
 		itemScrollData:       make(map[int]*internal.TextScrollData),
 		showingColorPicker:   false,
 		activeColorPickerIdx: -1,
-		lastRepeatTime:       time.Now(),
-		repeatDelay:          150 * time.Millisecond,
-		repeatInterval:       50 * time.Millisecond,
 	}
 }
 
@@ -205,8 +194,6 @@ func newOptionsListController(title string, items []ItemWithOptions) *optionsLis
 // This blocks until a selection is made or the user cancels.
 func OptionsList(title string, listOptions OptionListSettings, items []ItemWithOptions) (*OptionsListResult, error) {
 	window := internal.GetWindow()
-	renderer := window.Renderer
-	processor := internal.GetInputProcessor()
 
 	optionsListController := newOptionsListController(title, items)
 
@@ -223,75 +210,72 @@ func OptionsList(title string, listOptions OptionListSettings, items []ItemWithO
 		optionsListController.scrollTo(listOptions.InitialSelectedIndex)
 	}
 
-	running := true
-	cancelled := false
-	result := OptionsListResult{
+	optionsListController.result = OptionsListResult{
 		Items:    items,
 		Selected: -1,
 	}
 
-	var err error
-
-	for running {
-		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-			switch event.(type) {
-			case *sdl.QuitEvent:
-				running = false
-				err = sdl.GetError()
-
-			case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent:
-				inputEvent := processor.ProcessSDLEvent(event.(sdl.Event))
-				if inputEvent == nil {
-					continue
-				}
-
-				if inputEvent.Pressed {
-					if optionsListController.showingColorPicker {
-						optionsListController.handleColorPickerInput(inputEvent)
-					} else {
-						optionsListController.handleOptionsInput(inputEvent, &running, &result, &cancelled)
-					}
-				} else {
-					optionsListController.handleInputEventRelease(inputEvent)
-				}
-			}
+	if RunScreen(optionsListController, optionsListController.screenOptions()) {
+		if err := sdl.GetError(); err != nil {
+			return nil, err
 		}
+	}
 
-		optionsListController.handleDirectionalRepeats()
+	if optionsListController.cancelled {
+		return nil, ErrCancelled
+	}
 
-		if window.Background != nil {
-			window.RenderBackground()
-		} else {
-			renderer.SetDrawColor(0, 0, 0, 255)
-			renderer.Clear()
-		}
+	return &optionsListController.result, nil
+}
 
-		// If showing the color picker, draw it; otherwise draw just the option list
-		if optionsListController.showingColorPicker &&
-			optionsListController.activeColorPickerIdx >= 0 &&
-			optionsListController.activeColorPickerIdx < len(optionsListController.Items) {
-			item := &optionsListController.Items[optionsListController.activeColorPickerIdx]
-			if item.colorPicker != nil {
-				item.colorPicker.draw(renderer)
-			}
-		} else {
-			optionsListController.render(renderer)
-		}
+func (olc *optionsListController) screenOptions() ScreenOptions {
+	options := DefaultScreenOptions()
+	options.InputDelay = olc.Settings.InputDelay
+	options.EnableHelp = olc.HelpEnabled
+	options.HelpTitle = fmt.Sprintf("%s Help", olc.Settings.Title)
+	options.HelpText = []string{
+		"Navigation Controls:",
+		"• Up / Down: Navigate through items",
+		"• Left / Right: Change option for current item",
+		"• A: Select or input text for keyboard options",
+		"• B: Cancel and exit",
+	}
+	return options
+}
 
-		renderer.Present()
+func (olc *optionsListController) HandleInput(inputEvent *InputEvent) {
+	if !inputEvent.Pressed {
+		return
+	}
 
-		sdl.Delay(16)
+	if olc.showingColorPicker {
+		olc.handleColorPickerInput(inputEvent)
+	} else {
+		olc.handleOptionsInput(inputEvent)
 	}
+}
+
+func (olc *optionsListController) Update() {}
 
-	if err != nil {
-		return nil, err
+func (olc *optionsListController) Render(renderer *sdl.Renderer) {
+	window := internal.GetWindow()
+	if window.Background != nil {
+		window.RenderBackground()
 	}
 
-	if cancelled {
-		return nil, ErrCancelled
+	// If showing the color picker, draw it; otherwise draw just the option list
+	if olc.showingColorPicker && olc.activeColorPickerIdx >= 0 && olc.activeColorPickerIdx < len(olc.Items) {
+		item := &olc.Items[olc.activeColorPickerIdx]
+		if item.colorPicker != nil {
+			item.colorPicker.draw(renderer)
+		}
+	} else {
+		olc.render(renderer)
 	}
+}
 
-	return &result, nil
+func (olc *optionsListController) Done() bool {
+	return olc.done
 }
 
 func (olc *optionsListController) calculateMaxVisibleItems(window *internal.Window) int32 {
@@ -375,148 +359,38 @@ func (olc *optionsListController) handleColorPickerInput(inputEvent *internal.Ev
 	}
 }
 
-func (olc *optionsListController) handleOptionsInput(inputEvent *internal.Event, running *bool, result *OptionsListResult, cancelled *bool) {
-	if !inputEvent.Pressed {
-		return
-	}
-
-	currentTime := time.Now()
-	if currentTime.Sub(olc.lastInputTime) < olc.Settings.InputDelay {
-		return
-	}
-
+func (olc *optionsListController) handleOptionsInput(inputEvent *internal.Event) {
 	switch inputEvent.Button {
-	case constants.VirtualButtonMenu:
-		olc.toggleHelp()
-		olc.lastInputTime = time.Now()
-
 	case constants.VirtualButtonB:
-		if olc.ShowingHelp {
-			olc.ShowingHelp = false
-		} else if !olc.Settings.DisableBackButton {
-			*running = false
-			*cancelled = true
+		if !olc.Settings.DisableBackButton {
+			olc.done = true
+			olc.cancelled = true
 		}
-		olc.lastInputTime = time.Now()
 
 	case constants.VirtualButtonA:
-		if olc.ShowingHelp {
-			olc.ShowingHelp = false
-		} else {
-			olc.handleAButton(running, result)
-		}
-		olc.lastInputTime = time.Now()
+		olc.handleAButton()
 
 	case constants.VirtualButtonStart:
-		if !olc.ShowingHelp && olc.SelectedIndex >= 0 && olc.SelectedIndex < len(olc.Items) {
-			*running = false
-			result.Selected = olc.SelectedIndex
+		if olc.SelectedIndex >= 0 && olc.SelectedIndex < len(olc.Items) {
+			olc.done = true
+			olc.result.Selected = olc.SelectedIndex
 		}
-		olc.lastInputTime = time.Now()
 
 	case constants.VirtualButtonLeft:
-		if !olc.ShowingHelp {
-			olc.cycleOptionLeft()
-			olc.heldDirections.left = true
-			olc.heldDirections.right = false
-			olc.lastRepeatTime = time.Now()
-		}
-		olc.lastInputTime = time.Now()
+		olc.cycleOptionLeft()
 
 	case constants.VirtualButtonRight:
-		if !olc.ShowingHelp {
-			olc.cycleOptionRight()
-			olc.heldDirections.right = true
-			olc.heldDirections.left = false
-			olc.lastRepeatTime = time.Now()
-		}
-		olc.lastInputTime = time.Now()
+		olc.cycleOptionRight()
 
 	case constants.VirtualButtonUp:
-		if olc.ShowingHelp {
-			olc.scrollHelpOverlay(-1)
-		} else {
-			olc.moveSelection(-1)
-			olc.heldDirections.up = true
-			olc.heldDirections.down = false
-			olc.lastRepeatTime = time.Now()
-		}
-		olc.lastInputTime = time.Now()
+		olc.moveSelection(-1)
 
 	case constants.VirtualButtonDown:
-		if olc.ShowingHelp {
-			olc.scrollHelpOverlay(1)
-		} else {
-			olc.moveSelection(1)
-			olc.heldDirections.down = true
-			olc.heldDirections.up = false
-			olc.lastRepeatTime = time.Now()
-		}
-		olc.lastInputTime = time.Now()
+		olc.moveSelection(1)
 	}
 }
 
-func (olc *optionsListController) handleInputEventRelease(inputEvent *internal.Event) {
-	switch inputEvent.Button {
-	case constants.VirtualButtonUp:
-		olc.heldDirections.up = false
-		olc.hasRepeated = false
-	case constants.VirtualButtonDown:
-		olc.heldDirections.down = false
-		olc.hasRepeated = false
-	case constants.VirtualButtonLeft:
-		olc.heldDirections.left = false
-		olc.hasRepeated = false
-	case constants.VirtualButtonRight:
-		olc.heldDirections.right = false
-		olc.hasRepeated = false
-	}
-}
-
-func (olc *optionsListController) handleDirectionalRepeats() {
-	if !olc.heldDirections.up && !olc.heldDirections.down && !olc.heldDirections.left && !olc.heldDirections.right {
-		olc.lastRepeatTime = time.Now()
-		olc.hasRepeated = false
-		return
-	}
-
-	timeSince := time.Since(olc.lastRepeatTime)
-
-	// Use repeatDelay for first repeat, then repeatInterval for subsequent repeats
-	threshold := olc.repeatInterval
-	if !olc.hasRepeated {
-		threshold = olc.repeatDelay
-	}
-
-	if timeSince >= threshold {
-		olc.lastRepeatTime = time.Now()
-		olc.hasRepeated = true
-
-		if olc.heldDirections.up {
-			if olc.ShowingHelp {
-				olc.scrollHelpOverlay(-1)
-			} else {
-				olc.moveSelection(-1)
-			}
-		} else if olc.heldDirections.down {
-			if olc.ShowingHelp {
-				olc.scrollHelpOverlay(1)
-			} else {
-				olc.moveSelection(1)
-			}
-		} else if olc.heldDirections.left {
-			if !olc.ShowingHelp {
-				olc.cycleOptionLeft()
-			}
-		} else if olc.heldDirections.right {
-			if !olc.ShowingHelp {
-				olc.cycleOptionRight()
-			}
-		}
-	}
-}
-
-func (olc *optionsListController) handleAButton(running *bool, result *OptionsListResult) {
+func (olc *optionsListController) handleAButton() {
 	if olc.SelectedIndex >= 0 && olc.SelectedIndex < len(olc.Items) {
 		item := &olc.Items[olc.SelectedIndex]
 		if len(item.Options) > 0 && item.SelectedOption < len(item.Options) {
@@ -538,8 +412,8 @@ func (olc *optionsListController) handleAButton(running *bool, result *OptionsLi
 			case OptionTypeColorPicker:
 				olc.showColorPicker(olc.SelectedIndex)
 			case OptionTypeClickable:
-				*running = false
-				result.Selected = olc.SelectedIndex
+				olc.done = true
+				olc.result.Selected = olc.SelectedIndex
 			}
 		}
 	}
@@ -671,37 +545,7 @@ func (olc *optionsListController) scrollTo(index int) {
 	}
 }
 
-func (olc *optionsListController) toggleHelp() {
-	if !olc.HelpEnabled {
-		return
-	}
-
-	olc.ShowingHelp = !olc.ShowingHelp
-	if olc.ShowingHelp && olc.helpOverlay == nil {
-		helpLines := []string{
-			"Navigation Controls:",
-			"• Up / Down: Navigate through items",
-			"• Left / Right: Change option for current item",
-			"• A: Select or input text for keyboard options",
-			"• B: Cancel and exit",
-		}
-		olc.helpOverlay = newHelpOverlay(fmt.Sprintf("%s Help", olc.Settings.Title), helpLines)
-	}
-}
-
-func (olc *optionsListController) scrollHelpOverlay(direction int) {
-	if olc.helpOverlay == nil {
-		return
-	}
-	olc.helpOverlay.scroll(direction)
-}
-
 func (olc *optionsListController) render(renderer *sdl.Renderer) {
-	if olc.ShowingHelp && olc.helpOverlay != nil {
-		olc.helpOverlay.render(renderer, internal.Fonts.SmallFont)
-		return
-	}
-
 	scaleFactor := internal.GetScaleFactor()
 	window := internal.GetWindow()
 	titleFont := internal.Fonts.LargeSymbolFont
@@ -914,7 +758,7 @@ func (olc *optionsListController) render(renderer *sdl.Renderer) {
 		}
 	}
 
-	renderFooter(
+	RenderFooter(
 		renderer,
 		internal.Fonts.SmallFont,
 		olc.Settings.FooterHelpItems,
@@ -1,6 +1,7 @@
 package gabagool
 
 import (
+	"context"
 	"fmt"
 	"time"
 
@@ -17,6 +18,9 @@ type ProcessMessageOptions struct {
 	ShowThemeBackground bool
 	ShowProgressBar     bool
 	Progress            *atomic.Float64
+	// Context hides the message and returns its error once cancelled, without waiting for the function.
+	// ProcessMessageContext hands it, combined with the app-wide context, to the function so it can stop too.
+	Context context.Context
 }
 
 type processMessage struct {
@@ -30,11 +34,29 @@ type processMessage struct {
 	imageHeight     int32
 	showProgressBar bool
 	progress        *atomic.Float64
+	finished        chan struct{}
+}
+
+// processResult carries the function's return values back from its goroutine
+type processResult[T any] struct {
+	value T
+	err   error
 }
 
 // ProcessMessage displays a message while executing a function asynchronously.
 // The function is generic and returns the typed result of the function.
+// When the app quits or Context is cancelled first, ProcessMessage returns right away and the function
+// keeps running in the background; use ProcessMessageContext to let it stop.
 func ProcessMessage[T any](message string, options ProcessMessageOptions, fn func() (T, error)) (T, error) {
+	return ProcessMessageContext(message, options, func(context.Context) (T, error) {
+		return fn()
+	})
+}
+
+// ProcessMessageContext is ProcessMessage for functions that take a context. The context is cancelled
+// when the app quits, the device powers off or options.Context is cancelled, and ProcessMessageContext
+// then returns the context's error without waiting for the function.
+func ProcessMessageContext[T any](message string, options ProcessMessageOptions, fn func(ctx context.Context) (T, error)) (T, error) {
 	processor := &processMessage{
 		window:          internal.GetWindow(),
 		showBG:          options.ShowThemeBackground,
@@ -44,6 +66,7 @@ func ProcessMessage[T any](message string, options ProcessMessageOptions, fn fun
 		isProcessing:    true,
 		showProgressBar: options.ShowProgressBar,
 		progress:        options.Progress,
+		finished:        make(chan struct{}),
 	}
 
 	if options.Image != "" {
@@ -54,79 +77,92 @@ func ProcessMessage[T any](message string, options ProcessMessageOptions, fn fun
 		}
 	}
 
-	var result T
-	var fnError error
-
-	window := internal.GetWindow()
-	renderer := window.Renderer
-
-	processor.render(renderer)
-	renderer.Present()
+	parent := options.Context
+	if parent == nil {
+		parent = context.Background()
+	}
+	ctx, cancel := context.WithCancel(parent)
+	defer cancel()
+	stop := context.AfterFunc(internal.AppContext(), cancel)
+	defer stop()
 
-	resultChan := make(chan struct {
-		result T
-		err    error
-	}, 1)
+	// Buffered so the function can finish after ProcessMessageContext has returned
+	results := make(chan processResult[T], 1)
 
 	go func() {
-		res, err := fn()
-		resultChan <- struct {
-			result T
-			err    error
-		}{result: res, err: err}
+		value, err := fn(ctx)
+		results <- processResult[T]{value: value, err: err}
+		close(processor.finished)
 	}()
 
-	running := true
-	functionComplete := false
-	var quitErr error
+	screenOptions := DefaultScreenOptions()
+	screenOptions.RepeatButtons = nil
+	screenOptions.Context = options.Context
 
-	for running {
+	quit := RunScreen(processor, screenOptions)
 
-		for event := sdl.PollEvent(); event != nil; event = sdl.PollEvent() {
-			if _, ok := event.(*sdl.QuitEvent); ok {
-				running = false
-				quitErr = sdl.GetError()
-			}
-		}
+	if processor.imageTexture != nil {
+		processor.imageTexture.Destroy()
+	}
 
-		if !functionComplete {
-			select {
-			case processResult := <-resultChan:
-				result = processResult.result
-				fnError = processResult.err
-				functionComplete = true
-				processor.isProcessing = false
-				processor.completeTime = time.Now()
-			default:
+	var result processResult[T]
+	select {
+	case result = <-results:
+	default:
+		// The screen only ends before the function returns on a quit or cancellation
+		return result.value, stoppedError(ctx)
+	}
 
-			}
-		} else {
+	// Prioritize function error over quit error
+	if result.err != nil {
+		return result.value, result.err
+	}
 
-			if time.Since(processor.completeTime) > 350*time.Millisecond {
-				running = false
-			}
+	if quit {
+		if options.Context != nil && options.Context.Err() != nil {
+			return result.value, options.Context.Err()
 		}
+		if quitErr := sdl.GetError(); quitErr != nil {
+			return result.value, quitErr
+		}
+	}
 
-		processor.render(renderer)
-		renderer.Present()
+	return result.value, nil
+}
 
-		sdl.Delay(16)
+// stoppedError returns why ctx was stopped. The app-wide context cancels ctx asynchronously,
+// so it is checked directly too.
+func stoppedError(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
 	}
-
-	if processor.imageTexture != nil {
-		processor.imageTexture.Destroy()
+	if err := internal.AppContext().Err(); err != nil {
+		return err
 	}
+	return context.Canceled
+}
 
-	// Prioritize function error over quit error
-	if fnError != nil {
-		return result, fnError
+func (p *processMessage) HandleInput(inputEvent *InputEvent) {}
+
+func (p *processMessage) Update() {
+	if !p.isProcessing {
+		return
 	}
 
-	if quitErr != nil {
-		return result, quitErr
+	select {
+	case <-p.finished:
+		p.isProcessing = false
+		p.completeTime = time.Now()
+	default:
 	}
+}
+
+func (p *processMessage) Render(renderer *sdl.Renderer) {
+	p.render(renderer)
+}
 
-	return result, nil
+func (p *processMessage) Done() bool {
+	return !p.isProcessing && time.Since(p.completeTime) > 350*time.Millisecond
 }
 
 func (p *processMessage) render(renderer *sdl.Renderer) {
@@ -0,0 +1,61 @@
+package gabagool
+
+import (
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+	"github.com/veandco/go-sdl2/ttf"
+)
+
+// Padding is the spacing around a component, in pixels.
+type Padding = internal.Padding
+
+// Theme holds the colors, font and background of the active platform theme.
+type Theme = internal.Theme
+
+// FontsManager holds the themed fonts at each size.
+type FontsManager = internal.FontsManager
+
+// UniformPadding returns a Padding with the same value on every side.
+func UniformPadding(value int32) Padding {
+	return internal.UniformPadding(value)
+}
+
+// GetTheme returns the theme selected by Init.
+func GetTheme() Theme {
+	return internal.GetTheme()
+}
+
+// GetFonts returns the themed fonts loaded by Init.
+func GetFonts() *FontsManager {
+	return &internal.Fonts
+}
+
+// GetScaleFactor returns the scale factor for the current window width, relative to 1024px.
+func GetScaleFactor() float32 {
+	return internal.GetScaleFactor()
+}
+
+// RenderMultilineText draws text word-wrapped to maxWidth. The alignment defaults to centered,
+// in which case x is the center of each line.
+func RenderMultilineText(renderer *sdl.Renderer, text string, font *ttf.Font, maxWidth int32, x, startY int32, color sdl.Color, alignment ...constants.TextAlign) {
+	internal.RenderMultilineText(renderer, text, font, maxWidth, x, startY, color, alignment...)
+}
+
+// ImageTexture returns the image at path scaled down to fit within maxWidth by maxHeight, or nil until it has loaded.
+// Images are decoded in the background and cached up to the limit set with SetImageCacheLimit.
+// The texture belongs to the cache: do not destroy it, and call ImageTexture again every frame.
+func ImageTexture(path string, maxWidth, maxHeight int32) *sdl.Texture {
+	texture, _ := internal.GetImageLoader().Texture(path, maxWidth, maxHeight)
+	return texture
+}
+
+// SetImageCacheLimit sets how many bytes of textures the image cache keeps. The default is 48 MiB.
+func SetImageCacheLimit(bytes int64) {
+	internal.GetImageLoader().SetLimit(bytes)
+}
+
+// DrawRoundedRect fills rect in color with corners of the given radius.
+func DrawRoundedRect(renderer *sdl.Renderer, rect *sdl.Rect, radius int32, color sdl.Color) {
+	internal.DrawRoundedRect(renderer, rect, radius, color)
+}
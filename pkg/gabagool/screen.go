@@ -0,0 +1,251 @@
+package gabagool
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/constants"
+	"github.com/BrandonKowalski/gabagool/v2/pkg/gabagool/internal"
+	"github.com/veandco/go-sdl2/sdl"
+)
+
+// Screen is a single view driven by the shared runtime loop.
+// HandleInput receives every mapped press and release; held buttons listed in
+// ScreenOptions.RepeatButtons are delivered again as presses while they repeat.
+// Update runs once per frame before Render. The loop ends once Done reports true.
+type Screen interface {
+	HandleInput(event *InputEvent)
+	Update()
+	Render(renderer *sdl.Renderer)
+	Done() bool
+}
+
+// ResizableScreen can be implemented by a Screen that needs to re-layout when the window is resized.
+// HandleResize receives the new window size in pixels.
+type ResizableScreen interface {
+	HandleResize(width, height int32)
+}
+
+// ScreenOptions configures how the runtime drives a Screen.
+// InputDelay is the shortest time between two presses of RepeatButtons. Repeats of a held button
+// follow RepeatDelay and RepeatInterval instead. Other buttons are never delayed, so fast typing
+// and confirm presses are not dropped.
+type ScreenOptions struct {
+	InputDelay     time.Duration
+	RepeatDelay    time.Duration
+	RepeatInterval time.Duration
+	RepeatButtons  []constants.VirtualButton
+	EnableHelp     bool
+	HelpTitle      string
+	HelpText       []string
+
+	// Context stops the screen as if the app had quit once it is done.
+	// The app-wide context, cancelled on quit or power-off, is always observed.
+	Context context.Context
+}
+
+// DefaultScreenOptions repeats the d-pad with the same timing used by the built-in components.
+func DefaultScreenOptions() ScreenOptions {
+	return ScreenOptions{
+		InputDelay:     constants.DefaultInputDelay,
+		RepeatDelay:    150 * time.Millisecond,
+		RepeatInterval: 50 * time.Millisecond,
+		RepeatButtons: []constants.VirtualButton{
+			constants.VirtualButtonUp,
+			constants.VirtualButtonDown,
+			constants.VirtualButtonLeft,
+			constants.VirtualButtonRight,
+		},
+	}
+}
+
+type screenRuntime struct {
+	screen        Screen
+	options       ScreenOptions
+	helpOverlay   *HelpOverlay
+	lastInputTime time.Time
+
+	held           []InputEvent
+	lastRepeatTime time.Time
+	hasRepeated    bool
+}
+
+// RunScreen drives screen until it is done or the application is asked to quit.
+// It returns true when the loop ended because of a quit request or a cancelled context.
+// Start from DefaultScreenOptions to get the same repeat timing as the built-in components.
+func RunScreen(screen Screen, options ScreenOptions) bool {
+	defer flushInputRecording()
+
+	window := internal.GetWindow()
+	renderer := window.Renderer
+	processor := internal.GetInputProcessor()
+
+	rt := &screenRuntime{
+		screen:         screen,
+		options:        options,
+		lastInputTime:  time.Now(),
+		lastRepeatTime: time.Now(),
+	}
+
+	if options.EnableHelp {
+		rt.helpOverlay = NewHelpOverlay(options.HelpTitle, options.HelpText)
+	}
+
+	appCtx := internal.AppContext()
+
+	for !screen.Done() {
+		if appCtx.Err() != nil || (options.Context != nil && options.Context.Err() != nil) {
+			return true
+		}
+
+		for event := internal.PollEvent(); event != nil; event = internal.PollEvent() {
+			switch e := event.(type) {
+			case *sdl.QuitEvent:
+				if !internal.IsScriptedQuit(e) {
+					internal.CancelApp()
+				}
+				return true
+			case *sdl.KeyboardEvent, *sdl.ControllerButtonEvent, *sdl.ControllerAxisEvent, *sdl.JoyButtonEvent, *sdl.JoyAxisEvent, *sdl.JoyHatEvent, *sdl.UserEvent:
+				inputEvent := processor.ProcessSDLEvent(event)
+				if inputEvent == nil {
+					continue
+				}
+				rt.handleInput(inputEvent)
+			case *sdl.WindowEvent:
+				if e.Event == sdl.WINDOWEVENT_RESIZED {
+					if resizable, ok := screen.(ResizableScreen); ok {
+						resizable.HandleResize(e.Data1, e.Data2)
+					}
+				}
+			}
+
+			if screen.Done() {
+				return false
+			}
+		}
+
+		rt.handleRepeats()
+		if screen.Done() {
+			return false
+		}
+
+		internal.GetImageLoader().Upload(renderer)
+
+		screen.Update()
+		if screen.Done() {
+			return false
+		}
+
+		renderer.SetDrawColor(0, 0, 0, 255)
+		renderer.Clear()
+		renderer.SetDrawBlendMode(sdl.BLENDMODE_BLEND)
+
+		screen.Render(renderer)
+
+		if rt.helpOverlay != nil && rt.helpOverlay.ShowingHelp {
+			rt.helpOverlay.Render(renderer, internal.Fonts.SmallFont)
+		}
+
+		renderer.Present()
+		sdl.Delay(16)
+	}
+
+	return false
+}
+
+func (rt *screenRuntime) handleInput(inputEvent *InputEvent) {
+	if !inputEvent.Pressed {
+		rt.release(inputEvent.Button)
+		rt.screen.HandleInput(inputEvent)
+		return
+	}
+
+	if rt.throttled(inputEvent.Button) {
+		return
+	}
+
+	if slices.Contains(rt.options.RepeatButtons, inputEvent.Button) {
+		rt.release(inputEvent.Button)
+		rt.held = append(rt.held, *inputEvent)
+		rt.lastRepeatTime = time.Now()
+		rt.hasRepeated = false
+	}
+
+	rt.dispatch(inputEvent)
+}
+
+// dispatch routes a press to the help overlay while it is showing, otherwise to the screen.
+func (rt *screenRuntime) dispatch(inputEvent *InputEvent) {
+	if rt.helpOverlay == nil {
+		rt.screen.HandleInput(inputEvent)
+		return
+	}
+
+	if rt.helpOverlay.ShowingHelp {
+		switch inputEvent.Button {
+		case constants.VirtualButtonUp:
+			rt.helpOverlay.Scroll(-1)
+		case constants.VirtualButtonDown:
+			rt.helpOverlay.Scroll(1)
+		default:
+			rt.helpOverlay.Toggle()
+		}
+		return
+	}
+
+	if inputEvent.Button == constants.VirtualButtonMenu {
+		rt.helpOverlay.Toggle()
+		return
+	}
+
+	rt.screen.HandleInput(inputEvent)
+}
+
+func (rt *screenRuntime) release(button constants.VirtualButton) {
+	held := len(rt.held)
+	rt.held = slices.DeleteFunc(rt.held, func(e InputEvent) bool {
+		return e.Button == button
+	})
+	if len(rt.held) != held {
+		rt.hasRepeated = false
+	}
+}
+
+// handleRepeats re-delivers the most recently pressed held button, using
+// RepeatDelay for the first repeat and RepeatInterval after that.
+func (rt *screenRuntime) handleRepeats() {
+	if len(rt.held) == 0 {
+		rt.lastRepeatTime = time.Now()
+		rt.hasRepeated = false
+		return
+	}
+
+	threshold := rt.options.RepeatInterval
+	if !rt.hasRepeated {
+		threshold = rt.options.RepeatDelay
+	}
+
+	if time.Since(rt.lastRepeatTime) < threshold {
+		return
+	}
+
+	rt.lastRepeatTime = time.Now()
+	rt.hasRepeated = true
+
+	repeat := rt.held[len(rt.held)-1]
+	rt.dispatch(&repeat)
+}
+
+// throttled reports whether a press of one of RepeatButtons comes within InputDelay of the last one.
+// Presses that get through start the delay again.
+func (rt *screenRuntime) throttled(button constants.VirtualButton) bool {
+	if !slices.Contains(rt.options.RepeatButtons, button) {
+		return false
+	}
+	if time.Since(rt.lastInputTime) < rt.options.InputDelay {
+		return true
+	}
+	rt.lastInputTime = time.Now()
+	return false
+}